@@ -0,0 +1,330 @@
+package testcase
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"text/tabwriter"
+	"time"
+)
+
+// CompareBenchmarks runs the Spec tests of each Suite in benchmark mode,
+// and at the end, it prints a side-by-side comparison table about the implementations.
+// The table contains, for every test, the ns/op, B/op, allocs/op and the custom metrics,
+// along with the relative difference compared to the first Suite, which acts as the baseline.
+//
+// It is ideal to A/B test the supplier implementations of a role interface contract.
+//
+//	func BenchmarkStorage(b *testing.B) {
+//		testcase.CompareBenchmarks(b,
+//			StorageContract{Subject: memory.NewStorage},
+//			StorageContract{Subject: postgres.NewStorage},
+//		)
+//	}
+func CompareBenchmarks(b *testing.B, contracts ...Suite) {
+	b.Helper()
+	comparison := &benchmarkComparison{}
+	defer comparison.Report(b)
+	s := NewSpec(b)
+	defer s.Finish()
+	for _, c := range contracts {
+		c := c
+		name := comparison.implementationName(getSuiteName(c))
+		s.Context(name, func(s *Spec) {
+			s.benchmark = &benchmarkRecord{
+				Comparison:     comparison,
+				Implementation: name,
+			}
+			c.Spec(s)
+		}, Group(name))
+	}
+}
+
+type benchmarkComparison struct {
+	mutex           sync.Mutex
+	implementations []string
+	tests           []string
+	results         map[benchmarkResultKey]benchmarkResult
+}
+
+type benchmarkResultKey struct {
+	Implementation string
+	Test           string
+}
+
+type benchmarkResult struct {
+	N        int
+	Duration time.Duration
+	Mallocs  uint64
+	Bytes    uint64
+	Metrics  map[string]float64
+}
+
+func (r benchmarkResult) NsPerOp() float64 {
+	return float64(r.Duration.Nanoseconds()) / float64(r.N)
+}
+
+func (r benchmarkResult) AllocsPerOp() float64 {
+	return float64(r.Mallocs) / float64(r.N)
+}
+
+func (r benchmarkResult) BytesPerOp() float64 {
+	return float64(r.Bytes) / float64(r.N)
+}
+
+// implementationName makes the name unique, in case the same Suite type is used for multiple implementations.
+func (c *benchmarkComparison) implementationName(name string) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	unique := name
+	for i := 1; c.hasImplementation(unique); i++ {
+		unique = fmt.Sprintf(`%s#%02d`, name, i)
+	}
+	c.implementations = append(c.implementations, unique)
+	return unique
+}
+
+func (c *benchmarkComparison) hasImplementation(name string) bool {
+	for _, impl := range c.implementations {
+		if impl == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Record stores the result of a benchmark run.
+// testing.B executes a benchmark multiple times with increasing b.N,
+// so the last recorded run is the one with the most iterations.
+func (c *benchmarkComparison) Record(key benchmarkResultKey, result benchmarkResult) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.results == nil {
+		c.results = make(map[benchmarkResultKey]benchmarkResult)
+	}
+	if _, ok := c.results[key]; !ok && !c.hasTest(key.Test) {
+		c.tests = append(c.tests, key.Test)
+	}
+	c.results[key] = result
+}
+
+func (c *benchmarkComparison) hasTest(name string) bool {
+	for _, test := range c.tests {
+		if test == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Report logs the comparison table through the testing.B, next to the benchmark results.
+func (c *benchmarkComparison) Report(b *testing.B) {
+	b.Helper()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.results) == 0 {
+		return
+	}
+	b.Logf("--- COMPARE: %s\n%s", b.Name(), c.table())
+}
+
+func (c *benchmarkComparison) table() string {
+	buf := &bytes.Buffer{}
+	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	fmt.Fprint(w, "test\tmetric")
+	for _, impl := range c.implementations {
+		fmt.Fprintf(w, "\t%s", impl)
+	}
+	fmt.Fprintln(w)
+	for _, test := range c.tests {
+		for _, metric := range c.metrics(test) {
+			fmt.Fprintf(w, "%s\t%s", test, metric.Name)
+			var (
+				baseline    float64
+				hasBaseline bool
+			)
+			for i, impl := range c.implementations {
+				result, ok := c.results[benchmarkResultKey{Implementation: impl, Test: test}]
+				if !ok {
+					fmt.Fprint(w, "\t-")
+					continue
+				}
+				value, ok := metric.Value(result)
+				if !ok {
+					fmt.Fprint(w, "\t-")
+					continue
+				}
+				if i == 0 {
+					baseline, hasBaseline = value, true
+					fmt.Fprintf(w, "\t%s", formatBenchmarkValue(value))
+					continue
+				}
+				fmt.Fprintf(w, "\t%s %s", formatBenchmarkValue(value), formatBenchmarkDelta(baseline, value, hasBaseline))
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	_ = w.Flush()
+	return buf.String()
+}
+
+type benchmarkMetric struct {
+	Name  string
+	Value func(benchmarkResult) (float64, bool)
+}
+
+func (c *benchmarkComparison) metrics(test string) []benchmarkMetric {
+	metrics := []benchmarkMetric{
+		{Name: `ns/op`, Value: func(r benchmarkResult) (float64, bool) { return r.NsPerOp(), true }},
+		{Name: `B/op`, Value: func(r benchmarkResult) (float64, bool) { return r.BytesPerOp(), true }},
+		{Name: `allocs/op`, Value: func(r benchmarkResult) (float64, bool) { return r.AllocsPerOp(), true }},
+	}
+	var custom []string
+	for key, result := range c.results {
+		if key.Test != test {
+			continue
+		}
+		for name := range result.Metrics {
+			if !containsString(custom, name) {
+				custom = append(custom, name)
+			}
+		}
+	}
+	sort.Strings(custom)
+	for _, name := range custom {
+		name := name
+		metrics = append(metrics, benchmarkMetric{
+			Name: name,
+			Value: func(r benchmarkResult) (float64, bool) {
+				v, ok := r.Metrics[name]
+				return v, ok
+			},
+		})
+	}
+	return metrics
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func formatBenchmarkValue(v float64) string {
+	return fmt.Sprintf(`%.2f`, v)
+}
+
+func formatBenchmarkDelta(baseline, value float64, hasBaseline bool) string {
+	switch {
+	case !hasBaseline:
+		return `(~)`
+	case baseline == value:
+		return `(+0.00%)`
+	case baseline == 0:
+		return `(~)`
+	default:
+		return fmt.Sprintf(`(%+.2f%%)`, (value-baseline)/baseline*100)
+	}
+}
+
+// benchmarkRecord is set on the Spec of a compared implementation,
+// so the benchmark runs of its tests are recorded for the comparison.
+type benchmarkRecord struct {
+	Comparison     *benchmarkComparison
+	Implementation string
+}
+
+func (spec *Spec) lookupBenchmarkRecord() (*benchmarkRecord, bool) {
+	for _, s := range spec.specsFromCurrent() {
+		if s.benchmark != nil {
+			return s.benchmark, true
+		}
+	}
+	return nil, false
+}
+
+// benchmarkMeasurement measures the benchmark iterations, while the testing.B timer is running.
+// A nil benchmarkMeasurement is a valid, no-op measurement.
+type benchmarkMeasurement struct {
+	record  *benchmarkRecord
+	test    string
+	result  benchmarkResult
+	began   time.Time
+	mallocs uint64
+	bytes   uint64
+}
+
+func (spec *Spec) newBenchmarkMeasurement() *benchmarkMeasurement {
+	record, ok := spec.lookupBenchmarkRecord()
+	if !ok {
+		return nil
+	}
+	return &benchmarkMeasurement{
+		record: record,
+		test:   spec.name(),
+		result: benchmarkResult{Metrics: make(map[string]float64)},
+	}
+}
+
+// Begin reads the memory statistics before an iteration.
+// It is called before the testing.B timer is started, so its overhead is not measured by the testing.B.
+func (m *benchmarkMeasurement) Begin() {
+	if m == nil {
+		return
+	}
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	m.mallocs, m.bytes = ms.Mallocs, ms.TotalAlloc
+}
+
+// Start takes the timestamp immediately before the test block,
+// so the timer bookkeeping of the testing.B is not part of the measured duration.
+func (m *benchmarkMeasurement) Start() {
+	if m == nil {
+		return
+	}
+	m.began = time.Now()
+}
+
+// Stop adds the duration since Start immediately after the test block.
+func (m *benchmarkMeasurement) Stop() {
+	if m == nil {
+		return
+	}
+	m.result.Duration += time.Since(m.began)
+}
+
+// End reads the memory statistics after an iteration, once the testing.B timer is stopped.
+func (m *benchmarkMeasurement) End() {
+	if m == nil {
+		return
+	}
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	m.result.Mallocs += ms.Mallocs - m.mallocs
+	m.result.Bytes += ms.TotalAlloc - m.bytes
+	m.result.N++
+}
+
+func (m *benchmarkMeasurement) ReportMetric(n float64, unit string) {
+	if m == nil {
+		return
+	}
+	m.result.Metrics[unit] = n
+}
+
+func (m *benchmarkMeasurement) Record() {
+	if m == nil || m.result.N == 0 {
+		return
+	}
+	m.record.Comparison.Record(benchmarkResultKey{
+		Implementation: m.record.Implementation,
+		Test:           m.test,
+	}, m.result)
+}
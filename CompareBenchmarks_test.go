@@ -0,0 +1,134 @@
+package testcase
+
+import (
+	"flag"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase/assert"
+)
+
+type compareBenchmarksContract struct {
+	Name_ string
+	Sleep time.Duration
+
+	mutex sync.Mutex
+	Calls int
+}
+
+func (c *compareBenchmarksContract) Name() string { return c.Name_ }
+
+func (c *compareBenchmarksContract) Spec(s *Spec) {
+	s.Test(`sleep`, func(t *T) {
+		c.mutex.Lock()
+		c.Calls++
+		c.mutex.Unlock()
+		time.Sleep(c.Sleep)
+	})
+}
+
+// setBenchtime sets the global test.benchtime flag for the duration of the test.
+func setBenchtime(tb testing.TB, value string) {
+	tb.Helper()
+	benchtime := flag.Lookup(`test.benchtime`)
+	if benchtime == nil {
+		tb.Skip(`test.benchtime flag is not registered`)
+	}
+	og := benchtime.Value.String()
+	assert.NoError(tb, flag.Set(`test.benchtime`, value))
+	tb.Cleanup(func() { assert.NoError(tb, flag.Set(`test.benchtime`, og)) })
+}
+
+func TestCompareBenchmarks(t *testing.T) {
+	setBenchtime(t, `3x`)
+
+	fast := &compareBenchmarksContract{Name_: `fast`, Sleep: time.Microsecond}
+	slow := &compareBenchmarksContract{Name_: `slow`, Sleep: time.Millisecond}
+	testing.Benchmark(func(b *testing.B) {
+		CompareBenchmarks(b, fast, slow)
+	})
+	assert.True(t, 0 < fast.Calls)
+	assert.True(t, 0 < slow.Calls)
+}
+
+func TestBenchmarkComparison_table(t *testing.T) {
+	c := &benchmarkComparison{}
+	baseline := c.implementationName(`memory`)
+	other := c.implementationName(`postgres`)
+	duplicate := c.implementationName(`memory`)
+	assert.Equal(t, `memory#01`, duplicate)
+
+	c.Record(benchmarkResultKey{Implementation: baseline, Test: `find`}, benchmarkResult{
+		N:        10,
+		Duration: 1000 * time.Nanosecond,
+		Mallocs:  20,
+		Bytes:    100,
+		Metrics:  map[string]float64{`hits/op`: 2},
+	})
+	c.Record(benchmarkResultKey{Implementation: other, Test: `find`}, benchmarkResult{
+		N:        10,
+		Duration: 1500 * time.Nanosecond,
+		Mallocs:  10,
+		Bytes:    100,
+		Metrics:  map[string]float64{},
+	})
+
+	table := c.table()
+	lines := strings.Split(strings.TrimSpace(table), "\n")
+	assert.Equal(t, 5, len(lines), table)
+	assert.Contain(t, lines[0], `memory#01`)
+	assert.Contain(t, lines[1], `ns/op`)
+	assert.Contain(t, lines[1], `100.00`)
+	assert.Contain(t, lines[1], `150.00 (+50.00%)`)
+	assert.Contain(t, lines[2], `B/op`)
+	assert.Contain(t, lines[2], `10.00 (+0.00%)`)
+	assert.Contain(t, lines[3], `allocs/op`)
+	assert.Contain(t, lines[3], `1.00 (-50.00%)`)
+	assert.Contain(t, lines[4], `hits/op`)
+	assert.Contain(t, lines[4], `2.00`)
+	assert.Equal(t, 2, strings.Count(lines[4], ` -`), `missing values are marked with a dash`)
+}
+
+func TestCompareBenchmarks_reportMetric(t *testing.T) {
+	setBenchtime(t, `3x`)
+	comparison := &benchmarkComparison{}
+	testing.Benchmark(func(b *testing.B) {
+		s := NewSpec(b)
+		s.Context(`impl`, func(s *Spec) {
+			s.benchmark = &benchmarkRecord{Comparison: comparison, Implementation: `impl`}
+			s.Test(`test`, func(t *T) { t.ReportMetric(42, `hits/op`) })
+		}, Group(`impl`))
+		s.Finish()
+	})
+	result, ok := comparison.results[benchmarkResultKey{Implementation: `impl`, Test: `test`}]
+	assert.True(t, ok)
+	assert.Equal(t, map[string]float64{`hits/op`: 42}, result.Metrics)
+}
+
+func TestCompareBenchmarks_goexit(t *testing.T) {
+	setBenchtime(t, `3x`)
+	comparison := &benchmarkComparison{}
+	testing.Benchmark(func(b *testing.B) {
+		s := NewSpec(b)
+		s.Context(`impl`, func(s *Spec) {
+			s.benchmark = &benchmarkRecord{Comparison: comparison, Implementation: `impl`}
+			s.Test(`test`, func(t *T) { t.SkipNow() })
+		}, Group(`impl`))
+		s.Finish()
+	})
+	result, ok := comparison.results[benchmarkResultKey{Implementation: `impl`, Test: `test`}]
+	assert.True(t, ok, `iterations exited with runtime.Goexit should be recorded`)
+	assert.Equal(t, 1, result.N)
+}
+
+func TestCompareBenchmarks_restoresBenchtime(t *testing.T) {
+	benchtime := flag.Lookup(`test.benchtime`)
+	if benchtime == nil {
+		t.Skip(`test.benchtime flag is not registered`)
+	}
+	og := benchtime.Value.String()
+	t.Run(``, func(t *testing.T) { setBenchtime(t, `7x`) })
+	assert.Equal(t, og, benchtime.Value.String())
+}
@@ -0,0 +1,221 @@
+package testcase
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvVar is a Var that takes its value from an environment variable.
+// The raw value is parsed into V, which can be a string, bool, integer, float,
+// time.Duration, url.URL, *url.URL or a comma separated list of these.
+//
+// When the environment variable is not present, the Default is used.
+// If there is no Default either, then the tests of the Spec subtree where EnvVar is bound will fail,
+// or with SkipWhenMissing they will be skipped instead.
+//
+//	var DBURL = testcase.EnvVar[*url.URL]{
+//		Key:             `TEST_DB_CONNECTION_URL`,
+//		SkipWhenMissing: true,
+//		Reason:          `database is required for the integration tests`,
+//	}
+//
+//	func TestMyStorage(t *testing.T) {
+//		s := testcase.NewSpec(t)
+//		dbURL := DBURL.Bind(s)
+//		// ...
+//	}
+type EnvVar[V any] struct {
+	// Key is the environment variable's key.
+	Key string
+	// Default is an optional constructor for the value,
+	// which is used when the environment variable is not present.
+	Default VarInitFunc[V]
+	// Parse is an optional function to parse the raw environment variable value.
+	// When Parse is not provided, the value is parsed based on the type of V.
+	Parse func(raw string) (V, error)
+	// SkipWhenMissing will skip the tests instead of failing them,
+	// when the environment variable is not present, and there is no Default.
+	SkipWhenMissing bool
+	// Reason is an optional explanation why the environment variable is needed.
+	// It is included in the skip or failure message.
+	Reason string
+}
+
+const (
+	envVarMissingFormat      = `%s environment variable is not present`
+	envVarInvalidValueFormat = `%s environment variable has an invalid value: %s`
+)
+
+// Var returns the Var representation of EnvVar.
+// The type of the value is part of the Var's ID,
+// thus EnvVars of the same key with different types don't share their value.
+func (ev EnvVar[V]) Var() Var[V] {
+	return Var[V]{
+		ID:   fmt.Sprintf(`env:%s:%s`, ev.Key, reflect.TypeOf((*V)(nil)).Elem().String()),
+		Init: ev.init,
+	}
+}
+
+// Bind binds the EnvVar to the Spec,
+// and ensures that all tests in the Spec subtree
+// are skipped or failed when the environment variable is not present,
+// even if they don't access the value.
+func (ev EnvVar[V]) Bind(s *Spec) Var[V] {
+	s.testingTB.Helper()
+	s.Before(ev.checkPresence)
+	return ev.Var().Bind(s)
+}
+
+// Get returns the parsed value of the environment variable.
+// When the environment variable is not present, and there is no Default,
+// the current test is skipped or failed.
+func (ev EnvVar[V]) Get(t *T) V {
+	t.Helper()
+	return ev.Var().Get(t)
+}
+
+// Lookup returns the parsed value of the environment variable, and reports whether it was present.
+func (ev EnvVar[V]) Lookup() (V, bool, error) {
+	raw, ok := os.LookupEnv(ev.Key)
+	if !ok {
+		return *new(V), false, nil
+	}
+	v, err := ev.parse(raw)
+	return v, true, err
+}
+
+func (ev EnvVar[V]) checkPresence(t *T) {
+	t.Helper()
+	if _, ok := os.LookupEnv(ev.Key); ok || ev.Default != nil {
+		return
+	}
+	ev.missing(t)
+}
+
+func (ev EnvVar[V]) missing(t *T) {
+	t.Helper()
+	msg := fmt.Sprintf(envVarMissingFormat, ev.Key)
+	if ev.Reason != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, ev.Reason)
+	}
+	if ev.SkipWhenMissing {
+		t.Skip(msg)
+	}
+	t.Fatal(msg)
+}
+
+func (ev EnvVar[V]) init(t *T) V {
+	t.Helper()
+	v, ok, err := ev.Lookup()
+	if err != nil {
+		t.Fatalf(envVarInvalidValueFormat, ev.Key, err.Error())
+	}
+	if ok {
+		return v
+	}
+	if ev.Default != nil {
+		return ev.Default(t)
+	}
+	ev.missing(t)
+	return v
+}
+
+func (ev EnvVar[V]) parse(raw string) (V, error) {
+	if ev.Parse != nil {
+		return ev.Parse(raw)
+	}
+	var v V
+	rv := reflect.ValueOf(&v).Elem()
+	if err := parseEnvValue(rv, raw); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+var (
+	typeDuration = reflect.TypeOf(time.Duration(0))
+	typeURL      = reflect.TypeOf(url.URL{})
+)
+
+func parseEnvValue(rv reflect.Value, raw string) error {
+	switch {
+	case rv.Type() == typeDuration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(d))
+		return nil
+
+	case rv.Type() == typeURL:
+		u, err := url.Parse(raw)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(*u))
+		return nil
+
+	case rv.Kind() == reflect.Pointer:
+		ptr := reflect.New(rv.Type().Elem())
+		if err := parseEnvValue(ptr.Elem(), raw); err != nil {
+			return err
+		}
+		rv.Set(ptr)
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(raw)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(n)
+
+	case reflect.Slice:
+		list := reflect.MakeSlice(rv.Type(), 0, 0)
+		if strings.TrimSpace(raw) != "" {
+			for _, rawElem := range strings.Split(raw, `,`) {
+				elem := reflect.New(rv.Type().Elem()).Elem()
+				if err := parseEnvValue(elem, strings.TrimSpace(rawElem)); err != nil {
+					return err
+				}
+				list = reflect.Append(list, elem)
+			}
+		}
+		rv.Set(list)
+
+	default:
+		return fmt.Errorf(`%s type is not supported`, rv.Type().String())
+	}
+	return nil
+}
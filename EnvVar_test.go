@@ -0,0 +1,234 @@
+package testcase_test
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase"
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/doubles"
+	"github.com/adamluzsi/testcase/random"
+	"github.com/adamluzsi/testcase/sandbox"
+)
+
+func ExampleEnvVar() {
+	var t *testing.T
+	s := testcase.NewSpec(t)
+
+	dbURL := testcase.EnvVar[*url.URL]{
+		Key:             `TEST_DB_CONNECTION_URL`,
+		SkipWhenMissing: true,
+		Reason:          `the storage tests require a database`,
+	}.Bind(s)
+
+	s.Test(``, func(t *testcase.T) {
+		_ = dbURL.Get(t).Host
+	})
+}
+
+func TestEnvVar_parse(t *testing.T) {
+	rnd := random.New(random.CryptoSeed{})
+	key := `TESTCASE_ENVVAR_` + rnd.StringNC(5, random.CharsetAlpha())
+
+	type MyString string
+
+	assertParsed := func(tb testing.TB, raw string, expected any, get func(t *testcase.T) any) {
+		tb.Helper()
+		testcase.SetEnv(tb, key, raw)
+		stub := &doubles.TB{}
+		defer stub.Finish()
+		s := testcase.NewSpec(stub)
+		var got any
+		s.Test(``, func(t *testcase.T) { got = get(t) })
+		s.Finish()
+		assert.False(tb, stub.IsFailed, stub.Logs.String())
+		assert.Equal(tb, expected, got)
+	}
+
+	t.Run(`string`, func(t *testing.T) {
+		ev := testcase.EnvVar[string]{Key: key}
+		assertParsed(t, `foo`, `foo`, func(t *testcase.T) any { return ev.Get(t) })
+	})
+	t.Run(`string based type`, func(t *testing.T) {
+		ev := testcase.EnvVar[MyString]{Key: key}
+		assertParsed(t, `foo`, MyString(`foo`), func(t *testcase.T) any { return ev.Get(t) })
+	})
+	t.Run(`int`, func(t *testing.T) {
+		ev := testcase.EnvVar[int]{Key: key}
+		assertParsed(t, `42`, 42, func(t *testcase.T) any { return ev.Get(t) })
+	})
+	t.Run(`bool`, func(t *testing.T) {
+		ev := testcase.EnvVar[bool]{Key: key}
+		assertParsed(t, `true`, true, func(t *testcase.T) any { return ev.Get(t) })
+	})
+	t.Run(`float`, func(t *testing.T) {
+		ev := testcase.EnvVar[float64]{Key: key}
+		assertParsed(t, `4.2`, 4.2, func(t *testcase.T) any { return ev.Get(t) })
+	})
+	t.Run(`time.Duration`, func(t *testing.T) {
+		ev := testcase.EnvVar[time.Duration]{Key: key}
+		assertParsed(t, `1m30s`, time.Minute+30*time.Second, func(t *testcase.T) any { return ev.Get(t) })
+	})
+	t.Run(`url`, func(t *testing.T) {
+		ev := testcase.EnvVar[*url.URL]{Key: key}
+		expected, err := url.Parse(`postgres://localhost:5432/db`)
+		assert.NoError(t, err)
+		assertParsed(t, `postgres://localhost:5432/db`, expected, func(t *testcase.T) any { return ev.Get(t) })
+	})
+	t.Run(`comma separated list`, func(t *testing.T) {
+		ev := testcase.EnvVar[[]string]{Key: key}
+		assertParsed(t, `foo, bar,baz`, []string{`foo`, `bar`, `baz`}, func(t *testcase.T) any { return ev.Get(t) })
+	})
+	t.Run(`comma separated list of integers`, func(t *testing.T) {
+		ev := testcase.EnvVar[[]int]{Key: key}
+		assertParsed(t, `1,2,3`, []int{1, 2, 3}, func(t *testcase.T) any { return ev.Get(t) })
+	})
+	t.Run(`custom parser`, func(t *testing.T) {
+		ev := testcase.EnvVar[int]{Key: key, Parse: func(raw string) (int, error) {
+			n, err := strconv.Atoi(raw)
+			return n * 2, err
+		}}
+		assertParsed(t, `21`, 42, func(t *testcase.T) any { return ev.Get(t) })
+	})
+}
+
+func TestEnvVar(t *testing.T) {
+	s := testcase.NewSpec(t)
+	s.Sequential()
+
+	key := `TESTCASE_ENVVAR_` + random.New(random.CryptoSeed{}).StringNC(5, random.CharsetAlpha())
+
+	envVar := testcase.Let(s, func(t *testcase.T) testcase.EnvVar[int] {
+		return testcase.EnvVar[int]{Key: key}
+	})
+	stub := testcase.Let(s, func(t *testcase.T) *doubles.TB {
+		stub := &doubles.TB{}
+		t.Defer(stub.Finish)
+		return stub
+	})
+	var (
+		value int
+		ran   bool
+	)
+	act := func(t *testcase.T) {
+		value, ran = 0, false
+		s := testcase.NewSpec(stub.Get(t))
+		v := envVar.Get(t).Bind(s)
+		s.Test(``, func(t *testcase.T) {
+			value = v.Get(t)
+			ran = true
+		})
+		sandbox.Run(s.Finish)
+	}
+
+	s.When(`environment variable is present`, func(s *testcase.Spec) {
+		s.Before(func(t *testcase.T) {
+			t.SetEnv(key, `42`)
+		})
+
+		s.Then(`value is parsed`, func(t *testcase.T) {
+			act(t)
+			t.Must.False(stub.Get(t).IsFailed)
+			t.Must.True(ran)
+			t.Must.Equal(42, value)
+		})
+
+		s.And(`the value is invalid`, func(s *testcase.Spec) {
+			s.Before(func(t *testcase.T) {
+				t.SetEnv(key, `forty-two`)
+			})
+
+			s.Then(`test fails with the key mentioned`, func(t *testcase.T) {
+				act(t)
+				t.Must.True(stub.Get(t).IsFailed)
+				t.Must.False(ran)
+				t.Must.Contain(stub.Get(t).Logs.String(), fmt.Sprintf(`%s environment variable has an invalid value`, key))
+			})
+		})
+	})
+
+	s.When(`environment variable is missing`, func(s *testcase.Spec) {
+		s.Before(func(t *testcase.T) {
+			t.UnsetEnv(key)
+		})
+
+		s.Then(`test fails`, func(t *testcase.T) {
+			act(t)
+			t.Must.True(stub.Get(t).IsFailed)
+			t.Must.False(ran)
+			t.Must.Contain(stub.Get(t).Logs.String(), fmt.Sprintf(`%s environment variable is not present`, key))
+		})
+
+		s.And(`SkipWhenMissing is set`, func(s *testcase.Spec) {
+			envVar.Let(s, func(t *testcase.T) testcase.EnvVar[int] {
+				ev := envVar.Super(t)
+				ev.SkipWhenMissing = true
+				ev.Reason = `the answer is needed`
+				return ev
+			})
+
+			s.Then(`test is skipped with the reason`, func(t *testcase.T) {
+				act(t)
+				t.Must.False(stub.Get(t).IsFailed)
+				t.Must.True(stub.Get(t).IsSkipped)
+				t.Must.False(ran)
+				t.Must.Contain(stub.Get(t).Logs.String(), `the answer is needed`)
+			})
+		})
+
+		s.And(`Default is provided`, func(s *testcase.Spec) {
+			envVar.Let(s, func(t *testcase.T) testcase.EnvVar[int] {
+				ev := envVar.Super(t)
+				ev.Default = func(t *testcase.T) int { return 24 }
+				return ev
+			})
+
+			s.Then(`default value is used`, func(t *testcase.T) {
+				act(t)
+				t.Must.False(stub.Get(t).IsFailed)
+				t.Must.True(ran)
+				t.Must.Equal(24, value)
+			})
+		})
+	})
+}
+
+func TestEnvVar_Get_withoutBinding(t *testing.T) {
+	key := `TESTCASE_ENVVAR_` + random.New(random.CryptoSeed{}).StringNC(5, random.CharsetAlpha())
+	stub := &doubles.TB{}
+	defer stub.Finish()
+	s := testcase.NewSpec(stub)
+	ev := testcase.EnvVar[string]{Key: key}
+	s.Test(``, func(t *testcase.T) {
+		out := sandbox.Run(func() { ev.Get(t) })
+		assert.False(t, out.OK)
+	})
+	s.Finish()
+	assert.True(t, stub.IsFailed)
+	assert.Contain(t, stub.Logs.String(), fmt.Sprintf(`%s environment variable is not present`, key))
+}
+
+func TestEnvVar_sameKeyWithDifferentTypes(t *testing.T) {
+	key := `TESTCASE_ENVVAR_` + random.New(random.CryptoSeed{}).StringNC(5, random.CharsetAlpha())
+	testcase.SetEnv(t, key, `42`)
+	stub := &doubles.TB{}
+	defer stub.Finish()
+	s := testcase.NewSpec(stub)
+	str := testcase.EnvVar[string]{Key: key}.Bind(s)
+	num := testcase.EnvVar[int]{Key: key}.Bind(s)
+	var (
+		gotStr string
+		gotNum int
+	)
+	s.Test(``, func(t *testcase.T) {
+		gotStr = str.Get(t)
+		gotNum = num.Get(t)
+	})
+	s.Finish()
+	assert.False(t, stub.IsFailed, stub.Logs.String())
+	assert.Equal(t, `42`, gotStr)
+	assert.Equal(t, 42, gotNum)
+}
@@ -0,0 +1,264 @@
+package testcase
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Dimension is a parameter of a test matrix.
+// Each of its values is bound to the Var in the matrix combinations.
+//
+//	var Storage = testcase.Var[Storage]{ID: `storage`}
+//
+//	testcase.Dimension[Storage]{
+//		Name: "storage",
+//		Var:  Storage,
+//		Values: []testcase.DimensionValue[Storage]{
+//			{Name: "memory", Init: func(t *testcase.T) Storage { return NewMemory() }},
+//			{Name: "postgres", Init: func(t *testcase.T) Storage { return NewPostgres(db) }},
+//		},
+//	}
+type Dimension[V any] struct {
+	// Name is the name of the dimension, which is used to name the matrix combination groups.
+	Name string
+	// Var is the variable which receives the dimension's value in the matrix combination.
+	Var Var[V]
+	// Values are the possible values of the dimension.
+	Values []DimensionValue[V]
+}
+
+// DimensionValue is a possible value of a Dimension.
+type DimensionValue[V any] struct {
+	// Name is the name of the value, which is used to name the matrix combination groups.
+	Name string
+	// Init is the constructor of the value for the tests.
+	Init VarInitFunc[V]
+	// BeforeAll is an optional hook that runs only once for the whole matrix,
+	// before the first test of a combination which uses this value.
+	// It is ideal to set up resources that are shared between the combinations,
+	// like a database connection for a given implementation.
+	// Cleanups registered during BeforeAll are executed after all the combinations are finished.
+	BeforeAll func(tb testing.TB)
+}
+
+// MatrixOption is an option for RunMatrix, like a Dimension or an ExcludeCombination.
+type MatrixOption interface {
+	configureMatrix(*matrix)
+}
+
+// ExcludeCombination will exclude from the test matrix the combinations
+// which match all the given dimension name and value name pairs.
+//
+//	testcase.ExcludeCombination(map[string]string{"storage": "memory", "cache": "on"})
+func ExcludeCombination(values map[ /* dimension */ string] /* value */ string) MatrixOption {
+	return matrixOptionFunc(func(m *matrix) {
+		m.excludes = append(m.excludes, values)
+	})
+}
+
+// RunMatrix runs each Suite against every combination of the Dimension values.
+// The combinations form a cartesian product of the dimensions,
+// each of them is executed in its own testing group named after the dimension values,
+// e.g. "storage=postgres format=json cache=on".
+//
+// Like RunSuite, it supports *testing.T, *testing.B, *testcase.T, *testcase.Spec and CustomTB test runners.
+func RunMatrix(tb any, suites []Suite, opts ...MatrixOption) {
+	if tb, ok := tb.(helper); ok {
+		tb.Helper()
+	}
+	s := toSpec(tb)
+	defer s.Finish()
+	m := &matrix{owner: s.testingTB}
+	for _, opt := range opts {
+		opt.configureMatrix(m)
+	}
+	if err := m.validateDimensions(); err != nil {
+		s.testingTB.Fatal(err.Error())
+	}
+	if err := m.validateExcludes(); err != nil {
+		s.testingTB.Fatal(err.Error())
+	}
+	combinations := m.combinations()
+	for _, c := range suites {
+		c := c
+		name := getSuiteName(c)
+		s.Context(name, func(s *Spec) {
+			for _, comb := range combinations {
+				comb := comb
+				cname := comb.String()
+				s.Context(cname, func(s *Spec) {
+					for _, v := range comb {
+						v.Bind(s)
+					}
+					c.Spec(s)
+				}, Group(cname))
+			}
+		}, Group(name))
+	}
+}
+
+type matrixOptionFunc func(*matrix)
+
+func (fn matrixOptionFunc) configureMatrix(m *matrix) { fn(m) }
+
+type matrix struct {
+	owner      testing.TB
+	dimensions []matrixDimension
+	excludes   []map[string]string
+}
+
+type matrixDimension struct {
+	Name   string
+	Values []matrixValue
+}
+
+type matrixValue struct {
+	Dimension string
+	Name      string
+	Bind      func(s *Spec)
+}
+
+type matrixCombination []matrixValue
+
+func (comb matrixCombination) String() string {
+	var parts []string
+	for _, v := range comb {
+		parts = append(parts, fmt.Sprintf(`%s=%s`, v.Dimension, v.Name))
+	}
+	return strings.Join(parts, ` `)
+}
+
+func (comb matrixCombination) matches(values map[string]string) bool {
+	var matched int
+	for _, v := range comb {
+		if name, ok := values[v.Dimension]; ok && name == v.Name {
+			matched++
+		}
+	}
+	return matched == len(values)
+}
+
+func (m *matrix) combinations() []matrixCombination {
+	combinations := []matrixCombination{nil}
+	for _, dim := range m.dimensions {
+		var next []matrixCombination
+		for _, comb := range combinations {
+			for _, v := range dim.Values {
+				next = append(next, append(append(matrixCombination{}, comb...), v))
+			}
+		}
+		combinations = next
+	}
+	var out []matrixCombination
+	for _, comb := range combinations {
+		if !m.isExcluded(comb) {
+			out = append(out, comb)
+		}
+	}
+	return out
+}
+
+func (m *matrix) isExcluded(comb matrixCombination) bool {
+	for _, values := range m.excludes {
+		if comb.matches(values) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateDimensions ensures that the matrix has at least one dimension, and every dimension has values,
+// so the suites don't run silently without combinations or in a nameless group.
+func (m *matrix) validateDimensions() error {
+	if len(m.dimensions) == 0 {
+		return fmt.Errorf(`RunMatrix requires at least one Dimension`)
+	}
+	for _, dim := range m.dimensions {
+		if len(dim.Values) == 0 {
+			return fmt.Errorf(`Dimension has no Values: %q`, dim.Name)
+		}
+	}
+	return nil
+}
+
+// validateExcludes ensures that the excluded combinations refer to known dimensions and values,
+// so a typo doesn't silently exclude nothing.
+func (m *matrix) validateExcludes() error {
+	for _, values := range m.excludes {
+		for dimName, valName := range values {
+			dim, ok := m.dimension(dimName)
+			if !ok {
+				return fmt.Errorf(`ExcludeCombination refers to an unknown dimension: %q`, dimName)
+			}
+			if !dim.hasValue(valName) {
+				return fmt.Errorf(`ExcludeCombination refers to an unknown value of the %q dimension: %q`, dimName, valName)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *matrix) dimension(name string) (matrixDimension, bool) {
+	for _, dim := range m.dimensions {
+		if dim.Name == name {
+			return dim, true
+		}
+	}
+	return matrixDimension{}, false
+}
+
+func (dim matrixDimension) hasValue(name string) bool {
+	for _, v := range dim.Values {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (dim Dimension[V]) configureMatrix(m *matrix) {
+	md := matrixDimension{Name: dim.Name}
+	for _, dv := range dim.Values {
+		dv := dv
+		var (
+			once sync.Once
+			// ok tells whether the BeforeAll hook was finished without a failure.
+			// When it failed, the later combinations fail as well, instead of running without the resource.
+			ok bool
+		)
+		md.Values = append(md.Values, matrixValue{
+			Dimension: dim.Name,
+			Name:      dv.Name,
+			Bind: func(s *Spec) {
+				if dv.BeforeAll != nil {
+					s.BeforeAll(func(tb testing.TB) {
+						tb.Helper()
+						once.Do(func() {
+							dv.BeforeAll(matrixTB{TB: tb, owner: m.owner})
+							ok = !tb.Failed()
+						})
+						if !ok {
+							tb.Fatalf(`BeforeAll of %s=%s failed`, dim.Name, dv.Name)
+						}
+					})
+				}
+				dim.Var.Let(s, dv.Init)
+			},
+		})
+	}
+	m.dimensions = append(m.dimensions, md)
+}
+
+// matrixTB is used for the BeforeAll hooks of the dimension values.
+// It registers cleanups to the testing.TB of the whole matrix,
+// so the resources can be shared between the combinations.
+type matrixTB struct {
+	testing.TB
+	owner testing.TB
+}
+
+func (tb matrixTB) Cleanup(fn func()) {
+	tb.owner.Cleanup(fn)
+}
@@ -0,0 +1,224 @@
+package testcase_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/doubles"
+	"github.com/adamluzsi/testcase/sandbox"
+)
+
+type MatrixExampleSuite struct {
+	Storage testcase.Var[string]
+	Format  testcase.Var[string]
+
+	mutex sync.Mutex
+	Got   []string
+}
+
+func (c *MatrixExampleSuite) Name() string { return "MatrixExampleSuite" }
+
+func (c *MatrixExampleSuite) Spec(s *testcase.Spec) {
+	s.Test(``, func(t *testcase.T) {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.Got = append(c.Got, c.Storage.Get(t)+"/"+c.Format.Get(t))
+	})
+}
+
+type matrixSuiteFunc func(s *testcase.Spec)
+
+func (fn matrixSuiteFunc) Spec(s *testcase.Spec) { fn(s) }
+
+func TestRunMatrix(t *testing.T) {
+	var (
+		storage = testcase.Var[string]{ID: `storage`}
+		format  = testcase.Var[string]{ID: `format`}
+	)
+	makeSuite := func() *MatrixExampleSuite {
+		return &MatrixExampleSuite{Storage: storage, Format: format}
+	}
+	var (
+		beforeAllMutex sync.Mutex
+		beforeAll      = map[string]int{}
+		cleanups       = map[string]int{}
+	)
+	dimensions := func() []testcase.MatrixOption {
+		newStorage := func(name string) testcase.DimensionValue[string] {
+			return testcase.DimensionValue[string]{
+				Name: name,
+				Init: func(t *testcase.T) string { return name },
+				BeforeAll: func(tb testing.TB) {
+					beforeAllMutex.Lock()
+					defer beforeAllMutex.Unlock()
+					beforeAll[name]++
+					tb.Cleanup(func() {
+						beforeAllMutex.Lock()
+						defer beforeAllMutex.Unlock()
+						cleanups[name]++
+					})
+				},
+			}
+		}
+		newFormat := func(name string) testcase.DimensionValue[string] {
+			return testcase.DimensionValue[string]{
+				Name: name,
+				Init: func(t *testcase.T) string { return name },
+			}
+		}
+		return []testcase.MatrixOption{
+			testcase.Dimension[string]{
+				Name:   "storage",
+				Var:    storage,
+				Values: []testcase.DimensionValue[string]{newStorage("memory"), newStorage("postgres")},
+			},
+			testcase.Dimension[string]{
+				Name:   "format",
+				Var:    format,
+				Values: []testcase.DimensionValue[string]{newFormat("json"), newFormat("xml")},
+			},
+		}
+	}
+
+	t.Run(`every combination is executed`, func(t *testing.T) {
+		suite := makeSuite()
+		t.Run(``, func(t *testing.T) {
+			testcase.RunMatrix(t, []testcase.Suite{suite}, dimensions()...)
+		})
+		assert.ContainExactly(t, []string{
+			"memory/json",
+			"memory/xml",
+			"postgres/json",
+			"postgres/xml",
+		}, suite.Got)
+	})
+
+	t.Run(`combinations can be excluded`, func(t *testing.T) {
+		suite := makeSuite()
+		t.Run(``, func(t *testing.T) {
+			opts := append(dimensions(), testcase.ExcludeCombination(map[string]string{
+				"storage": "memory",
+				"format":  "xml",
+			}))
+			testcase.RunMatrix(t, []testcase.Suite{suite}, opts...)
+		})
+		assert.ContainExactly(t, []string{
+			"memory/json",
+			"postgres/json",
+			"postgres/xml",
+		}, suite.Got)
+	})
+
+	t.Run(`BeforeAll is shared per dimension value`, func(t *testing.T) {
+		beforeAll = map[string]int{}
+		cleanups = map[string]int{}
+		a, b := makeSuite(), makeSuite()
+		t.Run(``, func(t *testing.T) {
+			testcase.RunMatrix(t, []testcase.Suite{a, b}, dimensions()...)
+			assert.Equal(t, map[string]int{}, cleanups, "cleanup should not run before the whole matrix is finished")
+		})
+		assert.Equal(t, map[string]int{"memory": 1, "postgres": 1}, beforeAll)
+		assert.Equal(t, map[string]int{"memory": 1, "postgres": 1}, cleanups)
+	})
+
+	t.Run(`groups are named after the combinations`, func(t *testing.T) {
+		var names []string
+		t.Run(``, func(t *testing.T) {
+			testcase.RunMatrix(t, []testcase.Suite{matrixSuiteFunc(func(s *testcase.Spec) {
+				s.Test(``, func(t *testcase.T) { names = append(names, t.Name()) })
+			})}, dimensions()...)
+		})
+		assert.Equal(t, 4, len(names))
+		for _, name := range names {
+			assert.Contain(t, name, "storage=")
+			assert.Contain(t, name, "format=")
+		}
+	})
+
+	t.Run(`without dimensions, the spec fails`, func(t *testing.T) {
+		stub := &doubles.TB{}
+		suite := makeSuite()
+		sandbox.Run(func() {
+			testcase.RunMatrix(stub, []testcase.Suite{suite})
+		})
+		stub.Finish()
+		assert.True(t, stub.IsFailed)
+		assert.Contain(t, stub.Logs.String(), `RunMatrix requires at least one Dimension`)
+		assert.Empty(t, suite.Got)
+	})
+
+	t.Run(`a dimension without values fails the spec`, func(t *testing.T) {
+		stub := &doubles.TB{}
+		suite := makeSuite()
+		sandbox.Run(func() {
+			opts := append(dimensions(), testcase.Dimension[string]{Name: "cache", Var: testcase.Var[string]{ID: `cache`}})
+			testcase.RunMatrix(stub, []testcase.Suite{suite}, opts...)
+		})
+		stub.Finish()
+		assert.True(t, stub.IsFailed)
+		assert.Contain(t, stub.Logs.String(), `Dimension has no Values: "cache"`)
+		assert.Empty(t, suite.Got)
+	})
+
+	t.Run(`excluding an unknown dimension fails the spec`, func(t *testing.T) {
+		stub := &doubles.TB{}
+		suite := makeSuite()
+		sandbox.Run(func() {
+			opts := append(dimensions(), testcase.ExcludeCombination(map[string]string{"storag": "memory"}))
+			testcase.RunMatrix(stub, []testcase.Suite{suite}, opts...)
+		})
+		stub.Finish()
+		assert.True(t, stub.IsFailed)
+		assert.Contain(t, stub.Logs.String(), `unknown dimension: "storag"`)
+		assert.Empty(t, suite.Got)
+	})
+
+	t.Run(`excluding an unknown value fails the spec`, func(t *testing.T) {
+		stub := &doubles.TB{}
+		suite := makeSuite()
+		sandbox.Run(func() {
+			opts := append(dimensions(), testcase.ExcludeCombination(map[string]string{"storage": "mysql"}))
+			testcase.RunMatrix(stub, []testcase.Suite{suite}, opts...)
+		})
+		stub.Finish()
+		assert.True(t, stub.IsFailed)
+		assert.Contain(t, stub.Logs.String(), `unknown value of the "storage" dimension: "mysql"`)
+	})
+
+	t.Run(`when BeforeAll fails, every combination with the value fails`, func(t *testing.T) {
+		stub := &doubles.TB{}
+		recorder := &doubles.RecorderTB{TB: stub}
+		recorder.Config.Passthrough = true // subtests are executed in their own goroutine
+		var ran []string
+		var beforeAllCalls int
+		sandbox.Run(func() {
+			testcase.RunMatrix(recorder, []testcase.Suite{matrixSuiteFunc(func(s *testcase.Spec) {
+				s.Test(``, func(t *testcase.T) { ran = append(ran, storage.Get(t)+"/"+format.Get(t)) })
+			})}, testcase.Dimension[string]{
+				Name: "storage",
+				Var:  storage,
+				Values: []testcase.DimensionValue[string]{{
+					Name: "postgres",
+					Init: func(t *testcase.T) string { return "postgres" },
+					BeforeAll: func(tb testing.TB) {
+						beforeAllCalls++
+						tb.Fatal("boom")
+					},
+				}},
+			}, testcase.Dimension[string]{
+				Name: "format",
+				Var:  format,
+				Values: []testcase.DimensionValue[string]{
+					{Name: "json", Init: func(t *testcase.T) string { return "json" }},
+					{Name: "xml", Init: func(t *testcase.T) string { return "xml" }},
+				},
+			})
+		})
+		stub.Finish()
+		assert.True(t, stub.IsFailed)
+		assert.Equal(t, 1, beforeAllCalls)
+		assert.Empty(t, ran, "none of the combinations should run without the BeforeAll resource")
+	})
+}
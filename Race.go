@@ -1,9 +1,22 @@
 package testcase
 
 import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
 	"runtime"
+	"strconv"
 	"sync"
 	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase/internal"
+	"github.com/adamluzsi/testcase/internal/caller"
+	"github.com/adamluzsi/testcase/internal/doubles"
+	"github.com/adamluzsi/testcase/internal/interleave"
 )
 
 // Race is a test helper that allows you to create a race situation easily.
@@ -15,7 +28,42 @@ import (
 // and run the testing suite with `go test -race`.
 // The race detector then should be able to notice issues with your implementation.
 func Race(fn1, fn2 func(), more ...func()) {
-	fns := append([]func(){fn1, fn2}, more...)
+	r := startRace(append([]func(){fn1, fn2}, more...))
+	<-r.Done
+	r.PropagateGoexit()
+}
+
+// RaceTimeout is a Race variant that protects the test from hanging forever,
+// when a function is blocked, for example, by a deadlock on a mutex or a channel.
+// If the functions don't finish within the timeout,
+// the test fails with the list of participants that didn't finish, along with their goroutine stack.
+func RaceTimeout(tb testing.TB, timeout time.Duration, fn1, fn2 func(), more ...func()) {
+	tb.Helper()
+	r := startRace(append([]func(){fn1, fn2}, more...))
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-r.Done:
+		r.PropagateGoexit()
+	case <-timer.C:
+		tb.Fatal(r.timeoutMessage(timeout))
+	}
+}
+
+type raceRound struct {
+	Done         chan struct{}
+	Participants []*raceParticipant
+}
+
+type raceParticipant struct {
+	Func        func()
+	GoroutineID int64
+	Finished    int32
+	Returned    int32
+}
+
+func startRace(fns []func()) *raceRound {
+	r := &raceRound{Done: make(chan struct{})}
 	var (
 		start sync.WaitGroup
 		rdy   sync.WaitGroup
@@ -24,21 +72,166 @@ func Race(fn1, fn2 func(), more ...func()) {
 	start.Add(1) // get ready for the race
 	wg.Add(len(fns))
 	rdy.Add(len(fns))
-	var total int32
 	for _, fn := range fns {
-		go func(blk func()) {
+		p := &raceParticipant{Func: fn}
+		r.Participants = append(r.Participants, p)
+		go func(p *raceParticipant) {
 			defer wg.Done()
+			defer atomic.StoreInt32(&p.Finished, 1)
+			atomic.StoreInt64(&p.GoroutineID, caller.GoroutineID())
 			rdy.Done()   // signal that participant is ready
 			start.Wait() // line up participants
-			blk()
-			atomic.AddInt32(&total, 1)
-		}(fn)
+			p.Func()
+			atomic.StoreInt32(&p.Returned, 1)
+		}(p)
 	}
 	runtime.Gosched()
 	rdy.Wait()   // wait until everyone lined up
 	start.Done() // start the race
-	wg.Wait()    // wait members to finish
-	if total != int32(len(fns)) {
+	go func() {
+		wg.Wait() // wait members to finish
+		close(r.Done)
+	}()
+	return r
+}
+
+// PropagateGoexit exits the current goroutine, when a participant exited with runtime.Goexit,
+// for example, due to a testing.TB#FailNow call.
+func (r *raceRound) PropagateGoexit() {
+	for _, p := range r.Participants {
+		if atomic.LoadInt32(&p.Returned) == 0 {
+			runtime.Goexit()
+		}
+	}
+}
+
+func (r *raceRound) timeoutMessage(timeout time.Duration) string {
+	msg := fmt.Sprintf("Race participants did not finish within %s:", timeout)
+	for i, p := range r.Participants {
+		if atomic.LoadInt32(&p.Finished) == 1 {
+			continue
+		}
+		msg += fmt.Sprintf("\n\nparticipant #%d (%s) is blocked:", i+1, funcLocation(p.Func))
+		if stack, ok := caller.GoroutineStack(atomic.LoadInt64(&p.GoroutineID)); ok {
+			msg += "\n" + stack
+		}
+	}
+	return msg
+}
+
+func funcLocation(fn func()) string {
+	rfn := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if rfn == nil {
+		return "unknown"
+	}
+	file, line := rfn.FileLine(rfn.Entry())
+	return fmt.Sprintf("%s:%d", filepath.Base(file), line)
+}
+
+// EnvKeyRaceSeed is the environment variable key that will be checked for an interleaving seed.
+// When it is set, RaceN and RaceExplore only execute the interleaving of the given seed,
+// which makes it possible to reproduce a failed interleaving.
+const EnvKeyRaceSeed = `TESTCASE_RACE_SEED`
+
+// RaceRun is a single execution of a RaceExplore scenario, with a seeded interleaving.
+type RaceRun struct {
+	testing.TB
+	// Seed is the seed of the interleaving.
+	Seed int64
+	// StallTimeout is the duration after which a participant that neither reached a race.Yield point nor finished
+	// is considered stalled, and the turn is handed over to another participant.
+	// After such a handover, the participants may run concurrently,
+	// thus the interleaving of the Seed is no longer reproducible, which is reported in the test log.
+	// When it is zero, a default of 25ms is used.
+	StallTimeout time.Duration
+}
+
+// Race executes each function in a different goroutine, following the interleaving of the Seed.
+// Only one function is executed at a time, and at the race.Yield points,
+// the execution is handed over to a function picked based on the Seed.
+// The same Seed results in the same interleaving, unless a participant stalls, see StallTimeout.
+func (r *RaceRun) Race(fn1, fn2 func(), more ...func()) {
+	fns := append([]func(){fn1, fn2}, more...)
+	scheduler := interleave.NewScheduler(r.Seed)
+	scheduler.StallTimeout = r.StallTimeout
+	returned := scheduler.Run(fns)
+	reported := make(map[int]struct{})
+	for _, i := range scheduler.Stalls() {
+		if _, ok := reported[i]; ok {
+			continue
+		}
+		reported[i] = struct{}{}
+		r.TB.Logf("race participant #%d (%s) stalled without reaching a race.Yield point, "+
+			"the interleaving of seed %d is not reproducible", i+1, funcLocation(fns[i]), r.Seed)
+	}
+	if returned != len(fns) {
 		runtime.Goexit()
 	}
 }
+
+// RaceExplore is a test helper that explores the possible interleavings of concurrent functions.
+// It executes the scenario n times, each time with a different interleaving seed.
+// The scenario should prepare a fresh state, execute the functions with RaceRun.Race,
+// and then make assertions on the outcome through the RaceRun.
+//
+// The interleaving is controlled by the race.Yield points placed in the code under test.
+// Unlike Race, which relies on the race detector and luck,
+// a failed interleaving is reported with its seed, and it can be reproduced
+// by setting the TESTCASE_RACE_SEED environment variable to the reported seed.
+//
+//	testcase.RaceExplore(t, 100, func(r *testcase.RaceRun) {
+//		var counter Counter
+//		r.Race(counter.Inc, counter.Inc)
+//		assert.Equal(r, 2, counter.Get())
+//	})
+func RaceExplore(tb testing.TB, n int, scenario func(r *RaceRun)) {
+	tb.Helper()
+	for _, seed := range raceSeeds(tb, n) {
+		alreadyFailed := tb.Failed()
+		recorder := &doubles.RecorderTB{TB: tb}
+		run := &RaceRun{TB: recorder, Seed: seed}
+		var finished bool
+		internal.RecoverGoexit(func() {
+			scenario(run)
+			finished = true
+		})
+		if finished && !recorder.IsFailed && (alreadyFailed || !tb.Failed()) {
+			recorder.CleanupNow()
+			continue
+		}
+		internal.Log(tb, fmt.Sprintf(`race interleaving failed, to reproduce it use %s=%d`, EnvKeyRaceSeed, seed))
+		recorder.Forward()
+		tb.FailNow()
+	}
+}
+
+// RaceN is a test helper that executes the functions n times,
+// each time with a different interleaving, like RaceExplore.
+// Assertions can be made in the functions with the testing.TB.
+func RaceN(tb testing.TB, n int, fn1, fn2 func(), more ...func()) {
+	tb.Helper()
+	RaceExplore(tb, n, func(r *RaceRun) {
+		r.Race(fn1, fn2, more...)
+	})
+}
+
+func raceSeeds(tb testing.TB, n int) []int64 {
+	tb.Helper()
+	if raw, ok := os.LookupEnv(EnvKeyRaceSeed); ok {
+		seed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			tb.Fatalf("%s has invalid seed integer value: %s", EnvKeyRaceSeed, raw)
+		}
+		return []int64{seed}
+	}
+	seed, err := makeSeed()
+	if err != nil {
+		tb.Fatal(err.Error())
+	}
+	rnd := rand.New(rand.NewSource(seed))
+	seeds := make([]int64, n)
+	for i := range seeds {
+		seeds[i] = rnd.Int63()
+	}
+	return seeds
+}
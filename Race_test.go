@@ -7,6 +7,7 @@ package testcase_test
 
 import (
 	"fmt"
+	"runtime"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -15,6 +16,8 @@ import (
 	"github.com/adamluzsi/testcase/assert"
 	"github.com/adamluzsi/testcase/internal"
 	"github.com/adamluzsi/testcase/internal/doubles"
+	"github.com/adamluzsi/testcase/race"
+	"github.com/adamluzsi/testcase/sandbox"
 )
 
 func TestRace(t *testing.T) {
@@ -71,3 +74,146 @@ func TestRace(t *testing.T) {
 		assert.Must(t).True(!afterRaceFinished, `after the second block exited, the exit should have propagated to the top one`)
 	})
 }
+
+func TestRaceExplore(t *testing.T) {
+	lostUpdate := func(r *testcase.RaceRun) {
+		var counter int
+		inc := func() {
+			c := counter
+			race.Yield()
+			counter = c + 1
+		}
+		r.Race(inc, inc)
+		assert.Equal(r, 2, counter)
+	}
+
+	t.Run(`when an interleaving fails, the seed is reported`, func(t *testing.T) {
+		testcase.UnsetEnv(t, testcase.EnvKeyRaceSeed)
+		stub := &doubles.TB{}
+		out := sandbox.Run(func() { testcase.RaceExplore(stub, 64, lostUpdate) })
+		assert.False(t, out.OK)
+		assert.True(t, stub.IsFailed)
+		assert.Contain(t, stub.Logs.String(), testcase.EnvKeyRaceSeed+`=`)
+	})
+
+	t.Run(`when the seed is set, only the given interleaving is executed`, func(t *testing.T) {
+		t.Setenv(testcase.EnvKeyRaceSeed, `42`)
+		var runs int
+		var seed int64
+		testcase.RaceExplore(t, 64, func(r *testcase.RaceRun) {
+			runs++
+			seed = r.Seed
+		})
+		assert.Equal(t, 1, runs)
+		assert.Equal(t, int64(42), seed)
+	})
+
+	t.Run(`the same seed results in the same interleaving`, func(t *testing.T) {
+		testcase.UnsetEnv(t, testcase.EnvKeyRaceSeed)
+		var seeds []int64
+		testcase.RaceExplore(t, 8, func(r *testcase.RaceRun) { seeds = append(seeds, r.Seed) })
+		interleaving := func(seed int64) string {
+			var events string
+			r := &testcase.RaceRun{TB: t, Seed: seed}
+			participant := func(name string) func() {
+				return func() {
+					for i := 0; i < 3; i++ {
+						events += name
+						race.Yield()
+					}
+				}
+			}
+			r.Race(participant(`a`), participant(`b`))
+			return events
+		}
+		for _, seed := range seeds {
+			assert.Equal(t, interleaving(seed), interleaving(seed))
+		}
+	})
+
+	t.Run(`when a participant stalls without yielding, the non-reproducible interleaving is reported`, func(t *testing.T) {
+		stub := &doubles.TB{}
+		r := &testcase.RaceRun{TB: stub, Seed: 42, StallTimeout: time.Millisecond}
+		r.Race(func() { time.Sleep(10 * time.Millisecond) }, func() {})
+		assert.False(t, stub.IsFailed)
+		assert.Contain(t, stub.Logs.String(), `stalled without reaching a race.Yield point`)
+		assert.Contain(t, stub.Logs.String(), `seed 42 is not reproducible`)
+	})
+
+	t.Run(`when no participant stalls, nothing is reported`, func(t *testing.T) {
+		stub := &doubles.TB{}
+		r := &testcase.RaceRun{TB: stub, Seed: 42}
+		r.Race(func() { race.Yield() }, func() {})
+		assert.Empty(t, stub.Logs.String())
+	})
+
+	t.Run(`when every interleaving passes, the test passes`, func(t *testing.T) {
+		var runs int32
+		testcase.RaceExplore(t, 16, func(r *testcase.RaceRun) {
+			atomic.AddInt32(&runs, 1)
+			var counter int32
+			inc := func() {
+				race.Yield()
+				atomic.AddInt32(&counter, 1)
+			}
+			r.Race(inc, inc, inc)
+			assert.Equal(r, int32(3), atomic.LoadInt32(&counter))
+		})
+		assert.Equal(t, int32(16), runs)
+	})
+}
+
+func TestRaceN(t *testing.T) {
+	testcase.UnsetEnv(t, testcase.EnvKeyRaceSeed)
+	var a, b int32
+	testcase.RaceN(t, 10, func() {
+		race.Yield()
+		atomic.AddInt32(&a, 1)
+	}, func() {
+		race.Yield()
+		atomic.AddInt32(&b, 1)
+	})
+	assert.Equal(t, int32(10), a)
+	assert.Equal(t, int32(10), b)
+}
+
+func TestRaceTimeout(t *testing.T) {
+	t.Run(`when every function finishes in time, then it behaves like Race`, func(t *testing.T) {
+		var sum int32
+		testcase.RaceTimeout(t, time.Second, func() {
+			atomic.AddInt32(&sum, 1)
+		}, func() {
+			atomic.AddInt32(&sum, 10)
+		})
+		assert.Equal(t, int32(11), sum)
+	})
+
+	t.Run(`when a function is blocked, then the test fails with the blocked participant's stack`, func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		stub := &doubles.TB{}
+		out := sandbox.Run(func() {
+			testcase.RaceTimeout(stub, 50*time.Millisecond, func() {}, func() {
+				<-release
+			})
+		})
+		assert.False(t, out.OK)
+		assert.True(t, stub.IsFailed)
+		logs := stub.Logs.String()
+		assert.Contain(t, logs, `participant #2 (Race_test.go:`)
+		assert.NotContain(t, logs, `participant #1`)
+		assert.Contain(t, logs, `goroutine `)
+		assert.Contain(t, logs, `chan receive`)
+	})
+
+	t.Run(`goexit propagated back from the lambdas`, func(t *testing.T) {
+		var afterRaceFinished bool
+		internal.RecoverGoexit(func() {
+			testcase.RaceTimeout(t, time.Second, func() {}, func() {
+				runtime.Goexit()
+			})
+			afterRaceFinished = true
+		})
+		assert.False(t, afterRaceFinished)
+	})
+}
@@ -88,12 +88,20 @@ type Spec struct {
 		Around    []hook
 		AroundAll []hookOnce
 	}
+	requirements []*requirement
 
 	immutable     bool
 	vars          *variables
 	parallel      bool
 	sequential    bool
 	skipBenchmark bool
+	reportAllocs  bool
+	bytes         *struct{ n int64 }
+	reportUnused  bool
+	sharedState   *sharedStateDetector
+	benchmark     *benchmarkRecord
+	skip          *struct{ reason string }
+	executed      int32
 	flaky         *assert.Eventually
 	eventually    *assert.Eventually
 	group         *struct{ name string }
@@ -275,6 +283,36 @@ func (spec *Spec) isBenchAllowedToRun() bool {
 	return true
 }
 
+func (spec *Spec) lookupSkip() (string, bool) {
+	spec.testingTB.Helper()
+	for _, context := range spec.specsFromParent() {
+		if context.skip != nil {
+			return context.skip.reason, true
+		}
+	}
+	return "", false
+}
+
+func (spec *Spec) lookupReportAllocs() bool {
+	spec.testingTB.Helper()
+	for _, context := range spec.specsFromParent() {
+		if context.reportAllocs {
+			return true
+		}
+	}
+	return false
+}
+
+func (spec *Spec) lookupSetBytes() (int64, bool) {
+	spec.testingTB.Helper()
+	for _, context := range spec.specsFromCurrent() {
+		if context.bytes != nil {
+			return context.bytes.n, true
+		}
+	}
+	return 0, false
+}
+
 func (spec *Spec) lookupRetryFlaky() (assert.Eventually, bool) {
 	spec.testingTB.Helper()
 	for _, context := range spec.specsFromParent() {
@@ -389,6 +427,11 @@ func (spec *Spec) runTB(tb testing.TB, blk func(*T)) {
 	}
 
 	spec.printDescription(newT(tb, spec))
+	if reason, ok := spec.lookupSkip(); ok {
+		tb.Skip(reason)
+	}
+	spec.checkRequirements(tb)
+	spec.markExecuted()
 
 	test := func(tb testing.TB) {
 		tb.Helper()
@@ -422,14 +465,34 @@ func (spec *Spec) runB(b *testing.B, blk func(*T)) {
 	if _, ok := spec.lookupRetryFlaky(); ok {
 		b.Skip(`skipping because flaky flag`)
 	}
+	if reason, ok := spec.lookupSkip(); ok {
+		b.Skip(reason)
+	}
+	spec.checkRequirements(b)
+	spec.markExecuted()
+	if spec.lookupReportAllocs() {
+		b.ReportAllocs()
+	}
+	if n, ok := spec.lookupSetBytes(); ok {
+		b.SetBytes(n)
+	}
+	measurement := spec.newBenchmarkMeasurement()
+	t.benchmark = measurement
 	benchCase := func() {
 		b.StopTimer()
 		b.Helper()
 		defer t.setUp()()
+		measurement.Begin()
 		b.StartTimer()
+		// the measurement ends after the timer is stopped, even if the test block exits with runtime.Goexit.
+		defer measurement.End()
 		defer b.StopTimer()
+		// the duration is measured immediately around the test block, without the timer bookkeeping.
+		defer measurement.Stop()
+		measurement.Start()
 		blk(t)
 	}
+	defer measurement.Record()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		benchCase()
@@ -458,6 +521,10 @@ func (spec *Spec) Finish() {
 		}
 		s.finished = true
 		s.immutable = true
+		if s.reportUnused {
+			tb := s.testingTB
+			tb.Cleanup(func() { s.reportUnusedLets(tb) })
+		}
 		tests = append(tests, s.tests...)
 		allHookOnce = append(allHookOnce, s.hooks.AroundAll...)
 	}))
@@ -69,6 +69,10 @@ type T struct {
 
 	// TODO: protect it against concurrency
 	timerPaused bool
+	// varInitStack holds the variables which init block is being executed with this *T.
+	varInitStack []string
+	// benchmark is the measurement of the current benchmark, when it is part of a CompareBenchmarks.
+	benchmark *benchmarkMeasurement
 
 	cache struct {
 		contexts []*Spec
@@ -169,6 +173,36 @@ func (t *T) Eventually(blk func(it assert.It), retryOpts ...interface{}) {
 	retry.Assert(t, blk)
 }
 
+// Soft runs the assertion block with soft assertions.
+// Instead of reporting the failed assertions one by one,
+// they are collected with their caller location, and reported as one numbered summary at the end of the block.
+// For more, read the documentation of assert.Collect.
+func (t *T) Soft(blk func(it assert.It)) {
+	t.TB.Helper()
+	assert.Collect(t, blk)
+}
+
+// ReportMetric adds "n unit" to the reported benchmark results, like testing.B#ReportMetric.
+// If the metric is per-iteration, the unit should end in "/op".
+// It is a no-op when the testing.TB can't report metrics, like when the test is not executed as a benchmark,
+// thus it is safe to use from any test block of a spec.
+// A testing.TB which wraps a *testing.B can support it by implementing the ReportMetric method.
+//
+// The metric is also included in the CompareBenchmarks report.
+func (t *T) ReportMetric(n float64, unit string) {
+	t.TB.Helper()
+	mr, ok := t.TB.(metricReporter)
+	if !ok {
+		return
+	}
+	mr.ReportMetric(n, unit)
+	t.benchmark.ReportMetric(n, unit)
+}
+
+type metricReporter interface {
+	ReportMetric(n float64, unit string)
+}
+
 type timerManager interface {
 	StartTimer()
 	StopTimer()
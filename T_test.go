@@ -21,6 +21,9 @@ import (
 
 var _ testing.TB = &testcase.T{}
 
+// testcase.T has the same ReportMetric signature as testing.B
+var _ interface{ ReportMetric(n float64, unit string) } = &testcase.T{}
+
 func TestT_implementsTestingTB(t *testing.T) {
 	testcase.RunSuite(t, contracts.TestingTB{
 		Subject: func(t *testcase.T) testing.TB {
@@ -467,6 +470,24 @@ func BenchmarkT_varDoesNotCountTowardsRun(b *testing.B) {
 	})
 }
 
+func TestT_Soft(t *testing.T) {
+	stub := &doubles.TB{}
+	s := testcase.NewSpec(stub)
+	var continued bool
+	s.Test(``, func(t *testcase.T) {
+		t.Soft(func(it assert.It) {
+			it.Should.True(false, "first")
+			it.Should.True(false, "second")
+		})
+		continued = true
+	})
+	stub.Finish()
+	s.Finish()
+	assert.Must(t).True(stub.IsFailed)
+	assert.Must(t).True(continued)
+	assert.Must(t).Contain(stub.Logs.String(), "[Collect] 2 assertion(s) failed.")
+}
+
 func TestT_SkipUntil(t *testing.T) {
 	const timeLayout = "2006-01-02"
 	const skipUntilFormat = "Skip time %s"
@@ -577,3 +598,46 @@ func TestT_Setenv(t *testing.T) {
 	})
 	s.Finish()
 }
+
+func TestT_ReportMetric(t *testing.T) {
+	s := testcase.NewSpec(t)
+	var ran bool
+	s.Test("it is a no-op during testing", func(t *testcase.T) {
+		t.ReportMetric(42, "hits/op")
+		ran = true
+	})
+	s.Finish()
+	assert.True(t, ran)
+}
+
+type reportMetricTB struct {
+	*doubles.TB
+	metrics map[string]float64
+}
+
+func (tb *reportMetricTB) ReportMetric(n float64, unit string) {
+	tb.metrics[unit] = n
+}
+
+func TestT_ReportMetric_wrappedTB(t *testing.T) {
+	tb := &reportMetricTB{TB: &doubles.TB{}, metrics: map[string]float64{}}
+	s := testcase.NewSpec(tb)
+	s.Test("", func(t *testcase.T) {
+		t.ReportMetric(42, "hits/op")
+	})
+	s.Finish()
+	tb.Finish()
+	assert.Equal(t, map[string]float64{"hits/op": 42}, tb.metrics)
+}
+
+func BenchmarkT_ReportMetric(b *testing.B) {
+	var ran bool
+	b.Run(``, func(b *testing.B) {
+		s := testcase.NewSpec(b)
+		s.Test(``, func(t *testcase.T) {
+			t.ReportMetric(42, "hits/op")
+			ran = true
+		}, testcase.ReportAllocs(), testcase.SetBytes(1024))
+	})
+	assert.Must(b).True(ran)
+}
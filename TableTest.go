@@ -26,6 +26,68 @@ func TableTest[TC sBlock | tBlock | any, Act tBlock | sBlock | func(*T, TC)](
 	sort.Slice(tests, func(i, j int) bool {
 		return tests[i].Desc < tests[j].Desc
 	})
+	runTableTest[TC, Act](s, tests, act)
+}
+
+// Cases is an ordered list of table test cases for TableTestCases.
+type Cases[TC any] []Case[TC]
+
+// Case is a single table test case.
+type Case[TC any] struct {
+	// Name is the description of the test case.
+	// It must be unique within the Cases.
+	Name string
+	// Case is the test case value, that will be passed to the act function.
+	Case TC
+	// Options are the SpecOption that should be applied to the test case,
+	// like Flaky, SkipBenchmark, Tag or Skip.
+	Options []SpecOption
+}
+
+const tableTestDuplicateCaseNameFormat = `table test case name is not unique: %q`
+
+// TableTestCases is the ordered variant of TableTest.
+// The test cases are declared in the order they are listed,
+// and each test case can have its own SpecOption list.
+// Duplicate test case names are reported at declaration time,
+// instead of being silently overwritten like a map literal would do.
+//
+//	testcase.TableTestCases(t, testcase.Cases[int]{
+//		{Name: "on 42", Case: 42},
+//		{Name: "on 24", Case: 24, Options: []testcase.SpecOption{testcase.Flaky(3)}},
+//	}, func(t *testcase.T, n int) {
+//		// ...
+//	})
+func TableTestCases[TC sBlock | tBlock | any, Act tBlock | sBlock | func(*T, TC)](
+	tbOrSpec any,
+	cases Cases[TC],
+	act Act,
+) {
+	s := toSpec(tbOrSpec)
+	s.testingTB.Helper()
+	var (
+		tests []tableTestTestCase[TC]
+		names = make(map[string]struct{})
+	)
+	for _, c := range cases {
+		if _, ok := names[c.Name]; ok {
+			s.testingTB.Fatalf(tableTestDuplicateCaseNameFormat, c.Name)
+		}
+		names[c.Name] = struct{}{}
+		tests = append(tests, tableTestTestCase[TC]{
+			Desc: c.Name,
+			TC:   c.Case,
+			Opts: c.Options,
+		})
+	}
+	runTableTest[TC, Act](s, tests, act)
+}
+
+func runTableTest[TC sBlock | tBlock | any, Act tBlock | sBlock | func(*T, TC)](
+	s *Spec,
+	tests []tableTestTestCase[TC],
+	act Act,
+) {
 	runT := func(test tableTestTestCase[TC], act func(t *T, tc TC)) {
 		switch tc := any(test.TC).(type) {
 		case sBlock:
@@ -34,18 +96,18 @@ func TableTest[TC sBlock | tBlock | any, Act tBlock | sBlock | func(*T, TC)](
 				s.Test("", func(t *T) {
 					act(t, test.TC)
 				})
-			})
+			}, test.Opts...)
 		case tBlock:
 			s.Context(test.Desc, func(s *Spec) {
 				s.Before(tc)
 				s.Test("", func(t *T) {
 					act(t, test.TC)
 				})
-			})
+			}, test.Opts...)
 		default:
 			s.Test(test.Desc, func(t *T) {
 				act(t, test.TC)
-			})
+			}, test.Opts...)
 		}
 	}
 	runS := func(test tableTestTestCase[TC], act sBlock) {
@@ -54,12 +116,12 @@ func TableTest[TC sBlock | tBlock | any, Act tBlock | sBlock | func(*T, TC)](
 			s.Context(test.Desc, func(s *Spec) {
 				tc(s)
 				act(s)
-			})
+			}, test.Opts...)
 		case tBlock:
 			s.Context(test.Desc, func(s *Spec) {
 				s.Before(tc)
 				act(s)
-			})
+			}, test.Opts...)
 		default:
 			panic(fmt.Sprintf("unsuported TableTest setup: TC<%T> <-> Act<%T>", test.TC, act))
 		}
@@ -80,4 +142,5 @@ func TableTest[TC sBlock | tBlock | any, Act tBlock | sBlock | func(*T, TC)](
 type tableTestTestCase[TC any] struct {
 	Desc string
 	TC   TC
+	Opts []SpecOption
 }
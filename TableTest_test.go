@@ -3,6 +3,9 @@ package testcase_test
 import (
 	"github.com/adamluzsi/testcase"
 	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal"
+	"github.com/adamluzsi/testcase/internal/doubles"
+	"github.com/adamluzsi/testcase/sandbox"
 	"sync"
 	"testing"
 )
@@ -253,3 +256,75 @@ func TestTableTest_actAsSpec(t *testing.T) {
 		})
 	})
 }
+
+func TestTableTestCases(t *testing.T) {
+	t.Run("cases are declared in the listed order", func(t *testing.T) {
+		t.Setenv(testcase.EnvKeyOrdering, string(testcase.OrderingAsDefined))
+		internal.SetupCacheFlush(t)
+
+		var out []int
+		s := testcase.NewSpec(t)
+		s.HasSideEffect()
+		testcase.TableTestCases(s, testcase.Cases[int]{
+			{Name: "3", Case: 3},
+			{Name: "1", Case: 1},
+			{Name: "2", Case: 2},
+		}, func(t *testcase.T, v int) {
+			out = append(out, v)
+		})
+		s.Finish()
+
+		assert.Equal(t, []int{3, 1, 2}, out)
+	})
+	t.Run("per case options are applied", func(t *testing.T) {
+		var out []int
+		s := testcase.NewSpec(t)
+		s.HasSideEffect()
+		testcase.TableTestCases(s, testcase.Cases[int]{
+			{Name: "1", Case: 1},
+			{Name: "2", Case: 2, Options: []testcase.SpecOption{testcase.Skip("not now")}},
+			{Name: "3", Case: 3, Options: []testcase.SpecOption{testcase.Tag("foo")}},
+		}, func(t *testcase.T, v int) {
+			if v == 3 {
+				t.Must.True(t.HasTag("foo"))
+			}
+			out = append(out, v)
+		})
+		s.Finish()
+
+		assert.ContainExactly(t, []int{1, 3}, out)
+	})
+	t.Run("cases with test block", func(t *testing.T) {
+		var out []int
+		s := testcase.NewSpec(t)
+		s.HasSideEffect()
+		v := testcase.LetValue(s, 0)
+		testcase.TableTestCases(s, testcase.Cases[func(t *testcase.T)]{
+			{Name: "1", Case: func(t *testcase.T) { v.Set(t, 1) }},
+			{Name: "2", Case: func(t *testcase.T) { v.Set(t, 2) }},
+		}, func(t *testcase.T) {
+			out = append(out, v.Get(t))
+		})
+		s.Finish()
+
+		assert.ContainExactly(t, []int{1, 2}, out)
+	})
+	t.Run("duplicate case names are reported at declaration", func(t *testing.T) {
+		stub := &doubles.TB{}
+		defer stub.Finish()
+		s := testcase.NewSpec(stub)
+		var declared bool
+		out := sandbox.Run(func() {
+			testcase.TableTestCases(s, testcase.Cases[int]{
+				{Name: "foo", Case: 1},
+				{Name: "bar", Case: 2},
+				{Name: "foo", Case: 3},
+			}, func(t *testcase.T, v int) {})
+			declared = true
+		})
+		assert.False(t, out.OK)
+		assert.False(t, declared)
+		assert.True(t, stub.IsFailed)
+		assert.Contain(t, stub.Logs.String(), `table test case name is not unique: "foo"`)
+	})
+}
@@ -3,6 +3,8 @@ package testcase
 import (
 	"fmt"
 	"reflect"
+
+	"github.com/adamluzsi/testcase/internal/reflects"
 )
 
 // Var is a testCase helper structure, that allows easy way to access testCase runtime variables.
@@ -40,6 +42,11 @@ type Var[V any] struct {
 	// In case OnLet is provided, the Var must be explicitly set to a Spec with a Let call
 	// else accessing the Var value will panic and warn about this.
 	OnLet func(s *Spec, v Var[V])
+	// Clone is an optional flag that makes the Var deep copy the value returned by the init block.
+	// This is useful when the init block returns an intentionally shared template value,
+	// like a package level map or a pointer to a fixture,
+	// and each test should receive its own copy that it can mutate without leaking the changes to other tests.
+	Clone bool
 }
 
 type VarInitFunc[V any] func(*T) V
@@ -61,9 +68,11 @@ func (v Var[V]) Get(t *T) V {
 	if v.OnLet != nil && !t.hasOnLetHookApplied(v.ID) {
 		t.Fatalf(varOnLetNotInitialized, v.ID)
 	}
+	t.traceVarAccess(v.ID)
 	v.execBefore(t)
 	if !t.vars.Knows(v.ID) && v.Init != nil {
-		t.vars.Let(v.ID, func(t *T) interface{} { return v.Init(t) })
+		initBlock := v.initFunc(v.Init)
+		t.vars.Let(v.ID, func(t *T) interface{} { return initBlock(t) })
 	}
 	rv, ok := t.vars.Get(t, v.ID).(V)
 	if !ok && t.vars.Get(t, v.ID) != nil {
@@ -86,9 +95,16 @@ func (v Var[V]) Let(s *Spec, blk VarInitFunc[V]) Var[V] {
 	s.testingTB.Helper()
 	v.onLet(s)
 	if blk == nil {
-		return let(s, v.ID, v.Init)
+		return let(s, v.ID, v.initFunc(v.Init))
+	}
+	return let(s, v.ID, v.initFunc(blk))
+}
+
+func (v Var[V]) initFunc(blk VarInitFunc[V]) VarInitFunc[V] {
+	if !v.Clone || blk == nil {
+		return blk
 	}
-	return let(s, v.ID, blk)
+	return func(t *T) V { return reflects.DeepCopy(blk(t)) }
 }
 
 type letWithSuperBlock[V any] func(t *T, super V) V
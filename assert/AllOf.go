@@ -0,0 +1,47 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase/internal/fmterror"
+)
+
+// AllOf is an assertion helper that allows you run AllOf.Test assertion blocks, where all of them must succeed.
+// Unlike using multiple Must assertions, every block is executed, and every failure is reported together on Finish.
+// common usage use-cases:
+//   - fan out scenario, where you need to check in parallel that every worker received the event.
+//   - list of independent expectations, where you want to see every broken one in a single test run.
+type AllOf struct {
+	TB   testing.TB
+	Fail func()
+
+	results blockResults
+}
+
+// Test will test a block of assertion that must succeed in order to make AllOf pass.
+// You can have as much AllOf.Test calls as you need, and all of them are executed.
+// Using Test is safe for concurrently.
+func (ao *AllOf) Test(blk func(it It)) {
+	ao.TB.Helper()
+	ao.results.Run(ao.TB, blk)
+}
+
+// Finish will check if all the assertions succeeded, and reports every failed one.
+func (ao *AllOf) Finish(msg ...interface{}) {
+	ao.TB.Helper()
+	failures := ao.results.Filter(false)
+	if len(failures) == 0 {
+		return
+	}
+	summary := &strings.Builder{}
+	summary.WriteString(fmterror.Message{
+		Method:  "AllOf",
+		Cause:   fmt.Sprintf("%d of the %d .Test failed", len(failures), ao.results.Total()),
+		Message: msg,
+	}.String())
+	formatBlockResults(summary, failures)
+	ao.TB.Log(summary.String())
+	ao.Fail()
+}
@@ -0,0 +1,71 @@
+package assert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/doubles"
+)
+
+func TestAllOf(t *testing.T) {
+	t.Run("when every .Test passes, AllOf yields no failure on .Finish", func(t *testing.T) {
+		stub := &doubles.TB{}
+		allOf := &assert.AllOf{TB: stub, Fail: stub.Fail}
+		allOf.Test(func(it assert.It) {})
+		allOf.Test(func(it assert.It) { it.Must.True(true) })
+		allOf.Finish()
+		assert.False(t, stub.IsFailed)
+	})
+	t.Run("when .Test blocks fail, every block is executed and every failure is reported together", func(t *testing.T) {
+		stub := &doubles.TB{}
+		allOf := &assert.AllOf{TB: stub, Fail: stub.Fail}
+		var ran []int
+		allOf.Test(func(it assert.It) {
+			ran = append(ran, 1)
+			it.Must.True(false, "first failure")
+		})
+		allOf.Test(func(it assert.It) {
+			ran = append(ran, 2)
+		})
+		allOf.Test(func(it assert.It) {
+			ran = append(ran, 3)
+			it.Must.True(false, "third failure")
+		})
+		allOf.Finish("msg")
+		assert.True(t, stub.IsFailed)
+		assert.Equal(t, []int{1, 2, 3}, ran)
+		logs := stub.Logs.String()
+		assert.Contain(t, logs, "[AllOf] 2 of the 3 .Test failed")
+		assert.Contain(t, logs, "msg")
+		assert.Contain(t, logs, "#1 AllOf_test.go:")
+		assert.Contain(t, logs, "#3 AllOf_test.go:")
+		assert.NotContain(t, logs, "#2 ")
+		assert.Contain(t, logs, "first failure")
+		assert.Contain(t, logs, "third failure")
+		assert.Equal(t, 1, strings.Count(logs, "[AllOf]"))
+	})
+	t.Run("cleanups of the .Test blocks run after leaving the block", func(t *testing.T) {
+		stub := &doubles.TB{}
+		allOf := &assert.AllOf{TB: stub, Fail: stub.Fail}
+		var cleanupRan bool
+		allOf.Test(func(it assert.It) {
+			it.Cleanup(func() { cleanupRan = true })
+			it.Must.True(false)
+		})
+		assert.True(t, cleanupRan)
+	})
+}
+
+func TestAllOf_Test_race(t *testing.T) {
+	stub := &doubles.TB{}
+	allOf := &assert.AllOf{TB: stub, Fail: stub.Fail}
+	testcase.Race(func() {
+		allOf.Test(func(it assert.It) {})
+	}, func() {
+		allOf.Test(func(it assert.It) { it.Must.True(false) })
+	}, func() {
+		allOf.Finish()
+	})
+}
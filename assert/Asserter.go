@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -41,6 +42,17 @@ func (a Asserter) fn(s any) {
 	a.Fail()
 }
 
+// fnWithDetails fails the assertion like fn, but it also logs the details, like a diff, after the failure message.
+// When there are no details, only the failure message is logged.
+func (a Asserter) fnWithDetails(s any, details string) {
+	a.TB.Helper()
+	a.TB.Log(s)
+	if details != "" {
+		a.TB.Logf("\n\n%s", details)
+	}
+	a.Fail()
+}
+
 func (a Asserter) try(blk func(a Asserter)) (ok bool) {
 	a.TB.Helper()
 	dtb := &doubles.TB{}
@@ -154,28 +166,47 @@ func (a Asserter) NotPanic(blk func(), msg ...any) {
 //   - IsEqual(oth T) bool
 //   - IsEqual(oth T) (bool, error)
 //
+// The comparison can be configured with EqualOption values passed along with the message arguments,
+// such as IgnoreFields, IgnoreUnexported, Comparer, FloatTolerance and EquateEmpty.
 func (a Asserter) Equal(expected, actually any, msg ...any) {
 	a.TB.Helper()
-	if a.eq(expected, actually) {
+	opts, msg := splitEqualOptions(msg)
+	if a.eq(expected, actually, opts...) {
 		return
 	}
 
-	a.TB.Log(fmterror.Message{
+	exp, act := pp.Format(expected), pp.Format(actually)
+	a.fnWithDetails(fmterror.Message{
 		Method:  "Equal",
 		Message: msg,
 		Values: []fmterror.Value{
 			{
 				Label: "expected",
-				Value: expected,
+				Value: fmterror.Formatted(exp),
 			},
 			{
 				Label: "actual",
-				Value: actually,
+				Value: fmterror.Formatted(act),
 			},
 		},
-	}.String())
-	a.TB.Logf("\n\n%s", pp.Diff(expected, actually))
-	a.Fail()
+	}.String(), a.diff(expected, actually, exp, act))
+}
+
+// diffPathsThreshold is the number of formatted lines above which
+// the differences are reported by their path, instead of a side-by-side diff of the whole values.
+const diffPathsThreshold = 50
+
+// diff describes the differences between the expected and the actual values.
+// It receives the values formatted with pp.Format as well, so they are not formatted again.
+func (a Asserter) diff(expected, actual any, formattedExpected, formattedActual string) string {
+	if strings.Count(formattedExpected, "\n") < diffPathsThreshold &&
+		strings.Count(formattedActual, "\n") < diffPathsThreshold {
+		return pp.DiffString(formattedExpected, formattedActual)
+	}
+	if diff := pp.DiffPaths(expected, actual); diff != "" {
+		return diff
+	}
+	return pp.DiffString(formattedExpected, formattedActual)
 }
 
 func (a Asserter) NotEqual(v, oth any, msg ...any) {
@@ -200,12 +231,8 @@ func (a Asserter) NotEqual(v, oth any, msg ...any) {
 	}.String())
 }
 
-func (a Asserter) eq(exp, act any) bool {
-	if isEqual, ok := a.tryIsEqual(exp, act); ok {
-		return isEqual
-	}
-
-	return reflect.DeepEqual(exp, act)
+func (a Asserter) eq(exp, act any, opts ...EqualOption) bool {
+	return newEquality(a, opts).IsEqual(exp, act)
 }
 
 func (a Asserter) tryIsEqual(exp, act any) (isEqual bool, ok bool) {
@@ -492,8 +519,12 @@ func (a Asserter) NotContain(haystack, v any, msg ...any) {
 	})
 }
 
+// ContainExactly asserts that the expected and the actual slice or map has exactly the same elements,
+// regardless of their order.
+// The element comparison can be configured with EqualOption values passed along with the message arguments.
 func (a Asserter) ContainExactly(expected, actual any, msg ...any) {
 	a.TB.Helper()
+	opts, msg := splitEqualOptions(msg)
 
 	exp := reflect.ValueOf(expected)
 	act := reflect.ValueOf(actual)
@@ -525,10 +556,10 @@ func (a Asserter) ContainExactly(expected, actual any, msg ...any) {
 
 	switch {
 	case exp.Kind() == reflect.Slice && exp.Type() == act.Type():
-		a.containExactlySlice(exp, act, msg)
+		a.containExactlySlice(exp, act, msg, opts)
 
 	case exp.Kind() == reflect.Map && exp.Type() == act.Type():
-		a.containExactlyMap(exp, act, msg)
+		a.containExactlyMap(exp, act, msg, opts)
 
 	default:
 		// TODO: maybe use Equal as default approach?
@@ -549,42 +580,44 @@ func (a Asserter) ContainExactly(expected, actual any, msg ...any) {
 	}
 }
 
-func (a Asserter) containExactlyMap(exp reflect.Value, act reflect.Value, msg []any) {
+func (a Asserter) containExactlyMap(exp reflect.Value, act reflect.Value, msg []any, opts []EqualOption) {
 	a.TB.Helper()
 
-	if a.eq(exp.Interface(), act.Interface()) {
+	if a.eq(exp.Interface(), act.Interface(), opts...) {
 		return
 	}
-	a.fn(fmterror.Message{
+	formattedExp, formattedAct := pp.Format(exp.Interface()), pp.Format(act.Interface())
+	a.fnWithDetails(fmterror.Message{
 		Method:  "ContainExactly",
 		Cause:   "SubMap content doesn't exactly match with expectations.",
 		Message: msg,
 		Values: []fmterror.Value{
-			{Label: "expected", Value: exp.Interface()},
-			{Label: "actual", Value: act.Interface()},
+			{Label: "expected", Value: fmterror.Formatted(formattedExp)},
+			{Label: "actual", Value: fmterror.Formatted(formattedAct)},
 		},
-	})
+	}, a.diff(exp.Interface(), act.Interface(), formattedExp, formattedAct))
 }
 
-func (a Asserter) containExactlySlice(exp reflect.Value, act reflect.Value, msg []any) {
+func (a Asserter) containExactlySlice(exp reflect.Value, act reflect.Value, msg []any, opts []EqualOption) {
 	a.TB.Helper()
 
 	if exp.Len() != act.Len() {
-		a.fn(fmterror.Message{
+		formattedExp, formattedAct := pp.Format(exp.Interface()), pp.Format(act.Interface())
+		a.fnWithDetails(fmterror.Message{
 			Method:  "ContainExactly",
 			Cause:   "Element count doesn't match",
 			Message: msg,
 			Values: []fmterror.Value{
 				{
 					Label: "actual:",
-					Value: act.Interface(),
+					Value: fmterror.Formatted(formattedAct),
 				},
 				{
 					Label: "value",
-					Value: exp.Interface(),
+					Value: fmterror.Formatted(formattedExp),
 				},
 			},
-		})
+		}, a.diff(exp.Interface(), act.Interface(), formattedExp, formattedAct))
 	}
 
 	for i := 0; i < exp.Len(); i++ {
@@ -593,27 +626,28 @@ func (a Asserter) containExactlySlice(exp reflect.Value, act reflect.Value, msg
 		var found bool
 	search:
 		for j := 0; j < act.Len(); j++ {
-			if a.eq(expectedValue, act.Index(j).Interface()) {
+			if a.eq(expectedValue, act.Index(j).Interface(), opts...) {
 				found = true
 				break search
 			}
 		}
 		if !found {
-			a.fn(fmterror.Message{
+			formattedExp, formattedAct := pp.Format(exp.Interface()), pp.Format(act.Interface())
+			a.fnWithDetails(fmterror.Message{
 				Method:  "ContainExactly",
 				Cause:   fmt.Sprintf("Element not found at index %d", i),
 				Message: msg,
 				Values: []fmterror.Value{
 					{
 						Label: "actual:",
-						Value: act.Interface(),
+						Value: fmterror.Formatted(formattedAct),
 					},
 					{
 						Label: "value",
 						Value: expectedValue,
 					},
 				},
-			})
+			}, a.diff(exp.Interface(), act.Interface(), formattedExp, formattedAct))
 		}
 	}
 }
@@ -625,6 +659,27 @@ func (a Asserter) AnyOf(blk func(a *AnyOf), msg ...any) {
 	blk(anyOf)
 }
 
+func (a Asserter) AllOf(blk func(a *AllOf), msg ...any) {
+	a.TB.Helper()
+	allOf := &AllOf{TB: a.TB, Fail: a.TB.Fail}
+	defer allOf.Finish(msg...)
+	blk(allOf)
+}
+
+func (a Asserter) NoneOf(blk func(a *NoneOf), msg ...any) {
+	a.TB.Helper()
+	noneOf := &NoneOf{TB: a.TB, Fail: a.TB.Fail}
+	defer noneOf.Finish(msg...)
+	blk(noneOf)
+}
+
+func (a Asserter) ExactlyOneOf(blk func(a *ExactlyOneOf), msg ...any) {
+	a.TB.Helper()
+	exactlyOneOf := &ExactlyOneOf{TB: a.TB, Fail: a.TB.Fail}
+	defer exactlyOneOf.Finish(msg...)
+	blk(exactlyOneOf)
+}
+
 func (a Asserter) isEmpty(v any) bool {
 	if v == nil {
 		return true
@@ -704,7 +759,7 @@ func (a Asserter) ErrorIs(expected, actual error, msg ...any) {
 		return
 	}
 
-	a.fn(fmterror.Message{
+	a.fnWithDetails(fmterror.Message{
 		Method:  "ErrorIs",
 		Cause:   "The actual error is not what was expected.",
 		Message: msg,
@@ -712,7 +767,7 @@ func (a Asserter) ErrorIs(expected, actual error, msg ...any) {
 			{Label: "expected", Value: expected},
 			{Label: "actual", Value: actual},
 		},
-	})
+	}, errorChain(actual))
 }
 
 func (a Asserter) NoError(err error, msg ...any) {
@@ -720,7 +775,7 @@ func (a Asserter) NoError(err error, msg ...any) {
 	if err == nil {
 		return
 	}
-	a.fn(fmterror.Message{
+	a.fnWithDetails(fmterror.Message{
 		Method:  "NoError",
 		Cause:   "Non-nil error value is received.",
 		Message: msg,
@@ -728,7 +783,72 @@ func (a Asserter) NoError(err error, msg ...any) {
 			{Label: "value", Value: err},
 			{Label: "error", Value: err.Error()},
 		},
-	})
+	}, errorChain(err))
+}
+
+// ErrorContains asserts that the error is not nil, and its message contains the substring.
+func (a Asserter) ErrorContains(err error, substr string, msg ...any) {
+	a.TB.Helper()
+	if err != nil && strings.Contains(err.Error(), substr) {
+		return
+	}
+	a.fnWithDetails(fmterror.Message{
+		Method:  "ErrorContains",
+		Cause:   "The error message doesn't contain the expected substring.",
+		Message: msg,
+		Values: []fmterror.Value{
+			{Label: "substring", Value: substr},
+			{Label: "error", Value: errorMessage(err)},
+		},
+	}, errorChain(err))
+}
+
+// ErrorMatches asserts that the error is not nil, and its message matches the regular expression pattern.
+// When the pattern is not a valid regular expression, the assertion fails with the compile error.
+func (a Asserter) ErrorMatches(err error, pattern string, msg ...any) {
+	a.TB.Helper()
+	rgx, cErr := regexp.Compile(pattern)
+	if cErr != nil {
+		a.fn(fmterror.Message{
+			Method:  "ErrorMatches",
+			Cause:   "The pattern is not a valid regular expression.",
+			Message: msg,
+			Values: []fmterror.Value{
+				{Label: "pattern", Value: pattern},
+				{Label: "error", Value: cErr.Error()},
+			},
+		})
+		return
+	}
+	if err != nil && rgx.MatchString(err.Error()) {
+		return
+	}
+	a.fnWithDetails(fmterror.Message{
+		Method:  "ErrorMatches",
+		Cause:   "The error message doesn't match the expected pattern.",
+		Message: msg,
+		Values: []fmterror.Value{
+			{Label: "pattern", Value: pattern},
+			{Label: "error", Value: errorMessage(err)},
+		},
+	}, errorChain(err))
+}
+
+// errorMessage returns the message of the error, or nil, when there is no error.
+func errorMessage(err error) any {
+	if err == nil {
+		return nil
+	}
+	return err.Error()
+}
+
+// errorChain formats the wrap chain of the error, to be logged after a failure message.
+// Without an error, there is no chain to log.
+func errorChain(err error) string {
+	if err == nil {
+		return ""
+	}
+	return "error chain:\n" + pp.ErrorChain(err)
 }
 
 func (a Asserter) Read(expected any, r io.Reader, msg ...any) {
@@ -770,7 +890,7 @@ func (a Asserter) Read(expected any, r io.Reader, msg ...any) {
 	if a.eq(exp, act) {
 		return
 	}
-	a.fn(fmterror.Message{
+	a.fnWithDetails(fmterror.Message{
 		Method:  FnMethod,
 		Cause:   "Read output is not as expected.",
 		Message: msg,
@@ -778,7 +898,7 @@ func (a Asserter) Read(expected any, r io.Reader, msg ...any) {
 			{Label: "expected", Value: exp},
 			{Label: "actual", Value: act},
 		},
-	})
+	}, pp.DiffString(fmt.Sprintf("%s", exp), fmt.Sprintf("%s", act)))
 }
 
 func (a Asserter) ReadAll(r io.Reader, msg ...any) []byte {
@@ -13,6 +13,7 @@ import (
 	"github.com/adamluzsi/testcase/sandbox"
 
 	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/pp"
 	"github.com/adamluzsi/testcase/random"
 )
 
@@ -23,6 +24,48 @@ func TestAsserter_Equal_pp(t *testing.T) {
 	t.Log(dtb.Logs.String())
 }
 
+func TestAsserter_Equal_diffPaths(t *testing.T) {
+	type Item struct {
+		Name  string
+		Price int
+	}
+	type Order struct{ Items []Item }
+	t.Run("when values are small, then side-by-side diff is used", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		assert.Should(dtb).Equal(Order{Items: []Item{{Price: 10}}}, Order{Items: []Item{{Price: 12}}})
+		assert.Contain(t, dtb.Logs.String(), "|")
+		assert.NotContain(t, dtb.Logs.String(), ".Items[0].Price: 10 -> 12")
+	})
+	t.Run("when values are big, then differences are reported by their path", func(t *testing.T) {
+		var exp, act Order
+		for i := 0; i < 100; i++ {
+			exp.Items = append(exp.Items, Item{Name: fmt.Sprintf("item-%d", i), Price: 10})
+			act.Items = append(act.Items, Item{Name: fmt.Sprintf("item-%d", i), Price: 10})
+		}
+		act.Items[42].Price = 12
+		dtb := &doubles.TB{}
+		assert.Should(dtb).Equal(exp, act)
+		assert.Contain(t, dtb.Logs.String(), ".Items[42].Price: 10 -> 12")
+	})
+	t.Run("when only a redacted field differs, then the difference is reported without its value", func(t *testing.T) {
+		type Secret struct {
+			Items    []Item
+			Password string `pp:"redact"`
+		}
+		var exp, act Secret
+		for i := 0; i < 100; i++ {
+			exp.Items = append(exp.Items, Item{Name: fmt.Sprintf("item-%d", i)})
+			act.Items = append(act.Items, Item{Name: fmt.Sprintf("item-%d", i)})
+		}
+		exp.Password, act.Password = "foo", "bar"
+		dtb := &doubles.TB{}
+		assert.Should(dtb).Equal(exp, act)
+		assert.True(t, dtb.IsFailed)
+		assert.Contain(t, dtb.Logs.String(), ".Password: <redacted field differs>")
+		assert.NotContain(t, dtb.Logs.String(), "foo")
+	})
+}
+
 func TestMust(t *testing.T) {
 	must := assert.Must(t)
 	stub := &doubles.TB{}
@@ -1028,6 +1071,68 @@ func TestAsserter_AnyOf(t *testing.T) {
 	})
 }
 
+func TestAsserter_AllOf(t *testing.T) {
+	t.Run(`on happy-path`, func(t *testing.T) {
+		stub := &doubles.TB{}
+		a := assert.Asserter{TB: stub, Fail: stub.Fail}
+		a.AllOf(func(a *assert.AllOf) {
+			a.Test(func(it assert.It) {})
+			a.Test(func(it assert.It) {})
+		})
+		assert.Must(t).Equal(false, stub.IsFailed, `testing.TB should not received any failure`)
+	})
+	t.Run(`on rainy-path`, func(t *testing.T) {
+		stub := &doubles.TB{}
+		a := assert.Asserter{TB: stub, Fail: stub.Fail}
+		a.AllOf(func(a *assert.AllOf) {
+			a.Test(func(it assert.It) {})
+			a.Test(func(it assert.It) { it.Must.True(false) })
+		})
+		assert.Must(t).Equal(true, stub.IsFailed, `testing.TB should failure`)
+	})
+}
+
+func TestAsserter_NoneOf(t *testing.T) {
+	t.Run(`on happy-path`, func(t *testing.T) {
+		stub := &doubles.TB{}
+		a := assert.Asserter{TB: stub, Fail: stub.Fail}
+		a.NoneOf(func(a *assert.NoneOf) {
+			a.Test(func(it assert.It) { it.Must.True(false) })
+		})
+		assert.Must(t).Equal(false, stub.IsFailed, `testing.TB should not received any failure`)
+	})
+	t.Run(`on rainy-path`, func(t *testing.T) {
+		stub := &doubles.TB{}
+		a := assert.Asserter{TB: stub, Fail: stub.Fail}
+		a.NoneOf(func(a *assert.NoneOf) {
+			a.Test(func(it assert.It) { it.Must.True(false) })
+			a.Test(func(it assert.It) {})
+		})
+		assert.Must(t).Equal(true, stub.IsFailed, `testing.TB should failure`)
+	})
+}
+
+func TestAsserter_ExactlyOneOf(t *testing.T) {
+	t.Run(`on happy-path`, func(t *testing.T) {
+		stub := &doubles.TB{}
+		a := assert.Asserter{TB: stub, Fail: stub.Fail}
+		a.ExactlyOneOf(func(a *assert.ExactlyOneOf) {
+			a.Test(func(it assert.It) { it.Must.True(false) })
+			a.Test(func(it assert.It) {})
+		})
+		assert.Must(t).Equal(false, stub.IsFailed, `testing.TB should not received any failure`)
+	})
+	t.Run(`on rainy-path`, func(t *testing.T) {
+		stub := &doubles.TB{}
+		a := assert.Asserter{TB: stub, Fail: stub.Fail}
+		a.ExactlyOneOf(func(a *assert.ExactlyOneOf) {
+			a.Test(func(it assert.It) {})
+			a.Test(func(it assert.It) {})
+		})
+		assert.Must(t).Equal(true, stub.IsFailed, `testing.TB should failure`)
+	})
+}
+
 func TestAsserter_Empty(t *testing.T) {
 	type TestCase struct {
 		Desc     string
@@ -1306,6 +1411,86 @@ func TestAsserter_NoError(t *testing.T) {
 	})
 }
 
+func TestAsserter_NoError_errorChain(t *testing.T) {
+	dtb := &doubles.TB{}
+	err := fmt.Errorf("outer: %w", errors.New("inner"))
+	asserter(dtb).NoError(err)
+	Equal(t, dtb.IsFailed, true)
+	Contain(t, dtb.Logs.String(), "error chain:\n"+pp.ErrorChain(err))
+}
+
+func TestAsserter_ErrorIs_errorChain(t *testing.T) {
+	dtb := &doubles.TB{}
+	err := fmt.Errorf("outer: %w", errors.New("inner"))
+	asserter(dtb).ErrorIs(errors.New("other"), err)
+	Equal(t, dtb.IsFailed, true)
+	Contain(t, dtb.Logs.String(), "error chain:\n"+pp.ErrorChain(err))
+
+	t.Run(`when the actual error is nil, then no error chain is logged`, func(t *testing.T) {
+		dtb := &doubles.TB{}
+		asserter(dtb).ErrorIs(errors.New("other"), nil)
+		Equal(t, dtb.IsFailed, true)
+		assert.NotContain(t, dtb.Logs.String(), "error chain:")
+	})
+}
+
+func TestAsserter_ErrorContains(t *testing.T) {
+	t.Run(`when the error message contains the substring, then it is accepted`, func(t *testing.T) {
+		dtb := &doubles.TB{}
+		asserter(dtb).ErrorContains(fmt.Errorf("create user: %w", errors.New("connection refused")), "connection refused")
+		Equal(t, dtb.IsFailed, false)
+	})
+	t.Run(`when the error message doesn't contain the substring, then it fails with the error chain`, func(t *testing.T) {
+		dtb := &doubles.TB{}
+		expectedMsg := []interface{}{"foo", "bar", "baz"}
+		err := fmt.Errorf("create user: %w", errors.New("timeout"))
+		asserter(dtb).ErrorContains(err, "connection refused", expectedMsg...)
+		Equal(t, dtb.IsFailed, true)
+		AssertFailMsg(t, dtb, expectedMsg)
+		Contain(t, dtb.Logs.String(), "[ErrorContains]")
+		Contain(t, dtb.Logs.String(), pp.ErrorChain(err))
+	})
+	t.Run(`when the error is nil, then it fails`, func(t *testing.T) {
+		dtb := &doubles.TB{}
+		asserter(dtb).ErrorContains(nil, "")
+		Equal(t, dtb.IsFailed, true)
+	})
+}
+
+func TestAsserter_ErrorMatches(t *testing.T) {
+	t.Run(`when the error message matches the pattern, then it is accepted`, func(t *testing.T) {
+		dtb := &doubles.TB{}
+		asserter(dtb).ErrorMatches(errors.New("user 42 not found"), `user \d+ not found`)
+		Equal(t, dtb.IsFailed, false)
+	})
+	t.Run(`when the error message doesn't match the pattern, then it fails with the error chain`, func(t *testing.T) {
+		dtb := &doubles.TB{}
+		expectedMsg := []interface{}{"foo", "bar", "baz"}
+		err := fmt.Errorf("lookup: %w", errors.New("user foo not found"))
+		asserter(dtb).ErrorMatches(err, `user \d+ not found`, expectedMsg...)
+		Equal(t, dtb.IsFailed, true)
+		AssertFailMsg(t, dtb, expectedMsg)
+		Contain(t, dtb.Logs.String(), "[ErrorMatches]")
+		Contain(t, dtb.Logs.String(), pp.ErrorChain(err))
+	})
+	t.Run(`when the error is nil, then it fails`, func(t *testing.T) {
+		dtb := &doubles.TB{}
+		asserter(dtb).ErrorMatches(nil, `.*`)
+		Equal(t, dtb.IsFailed, true)
+		assert.NotContain(t, dtb.Logs.String(), "error chain:")
+	})
+	t.Run(`when the pattern is invalid, then it fails with the compile error`, func(t *testing.T) {
+		dtb := &doubles.TB{}
+		out := sandbox.Run(func() {
+			asserter(dtb).ErrorMatches(errors.New("boom"), `(`)
+		})
+		assert.True(t, out.OK)
+		Equal(t, dtb.IsFailed, true)
+		Contain(t, dtb.Logs.String(), "The pattern is not a valid regular expression.")
+		Contain(t, dtb.Logs.String(), "missing closing )")
+	})
+}
+
 func TestAsserter_Read(t *testing.T) {
 	type TestCase struct {
 		Desc     string
@@ -1360,6 +1545,22 @@ func TestAsserter_Read(t *testing.T) {
 	}
 }
 
+func TestAsserter_Read_diff(t *testing.T) {
+	t.Setenv(pp.EnvKeyDiffFormat, pp.DiffFormatUnified)
+	dtb := &doubles.TB{}
+	asserter(dtb).Read("foo\nbar\nbaz", strings.NewReader("foo\nqux\nbaz"))
+	assert.True(t, dtb.IsFailed)
+	assert.Contain(t, dtb.Logs.String(), "-bar\n+qux\n")
+}
+
+func TestAsserter_ContainExactly_diff(t *testing.T) {
+	t.Setenv(pp.EnvKeyDiffFormat, pp.DiffFormatUnified)
+	dtb := &doubles.TB{}
+	asserter(dtb).ContainExactly(map[string]int{"foo": 1, "bar": 2}, map[string]int{"foo": 1, "bar": 3})
+	assert.True(t, dtb.IsFailed)
+	assert.Contain(t, dtb.Logs.String(), "-\t\"bar\": 2,\n+\t\"bar\": 3,\n")
+}
+
 func TestAsserter_ReadAll(t *testing.T) {
 	rnd := random.New(random.CryptoSeed{})
 	msg := []any{rnd.String(), rnd.String()}
@@ -0,0 +1,127 @@
+package assert
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/adamluzsi/testcase/clock"
+)
+
+// Backoff is a RetryStrategy that waits with an exponentially growing interval between the attempts.
+//
+// Backoff retries until the condition is met, or until any of its limits is reached.
+// When both Count and Timeout are set, both limits apply, and the retry stops at whichever is reached first.
+// If neither of them is defined, Backoff attempts to execute the condition only once.
+//
+// The waiting and the Timeout are measured with the clock package,
+// thus timecop.SetSpeed and timecop.Travel can accelerate long Eventually waits in tests.
+type Backoff struct {
+	// InitialInterval is the wait duration after the first failed attempt.
+	// When it is zero, Backoff retries without waiting.
+	InitialInterval time.Duration
+	// MaxInterval is the upper limit of the wait duration between two attempts.
+	// When it is zero, the interval grows without a limit.
+	MaxInterval time.Duration
+	// Multiplier is the growth factor of the interval after each failed attempt.
+	// When it is zero, the interval is doubled each time.
+	// A Multiplier of 1 makes the interval fixed.
+	Multiplier float64
+	// Jitter is the randomization factor between 0 and 1, applied on each interval.
+	// An interval is randomised within the [interval * (1 - Jitter), interval * (1 + Jitter)] range.
+	Jitter float64
+	// Seed is the random seed of the Jitter.
+	// The same Seed results in the same sequence of intervals, which makes a failing test reproducible.
+	Seed int64
+	// Count is the maximum number of retries after the first attempt.
+	// When it is zero, the number of retries is not limited.
+	Count int
+	// Timeout is the maximum time of retrying, measured from the first attempt.
+	// When it is zero, the retrying time is not limited.
+	Timeout time.Duration
+}
+
+// While will retry the condition while it returns true, and the limits of the Backoff are not reached.
+func (b Backoff) While(condition func() bool) {
+	b.WhileCtx(context.Background(), condition)
+}
+
+// WhileCtx will retry the condition while it returns true, the limits of the Backoff are not reached,
+// and the context is not cancelled.
+// The cancellation of the context also interrupts the waiting between two attempts.
+func (b Backoff) WhileCtx(ctx context.Context, condition func() bool) {
+	var (
+		rnd      = b.rand()
+		deadline = clock.TimeNow().Add(b.Timeout)
+	)
+	for retry := 0; ctx.Err() == nil && condition(); retry++ {
+		if b.isLimitReached(retry, deadline) {
+			return
+		}
+		wait := b.interval(retry, rnd)
+		if 0 < b.Timeout {
+			if remaining := deadline.Sub(clock.TimeNow()); remaining < wait {
+				wait = remaining
+			}
+		}
+		if 0 < wait {
+			select {
+			case <-clock.After(wait):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// Intervals returns the first n wait durations which Backoff uses between the attempts.
+func (b Backoff) Intervals(n int) []time.Duration {
+	var (
+		rnd       = b.rand()
+		intervals = make([]time.Duration, 0, n)
+	)
+	for retry := 0; retry < n; retry++ {
+		intervals = append(intervals, b.interval(retry, rnd))
+	}
+	return intervals
+}
+
+func (b Backoff) isLimitReached(retry int, deadline time.Time) bool {
+	if b.Count <= 0 && b.Timeout <= 0 {
+		return true
+	}
+	if 0 < b.Count && b.Count <= retry {
+		return true
+	}
+	if 0 < b.Timeout && !clock.TimeNow().Before(deadline) {
+		return true
+	}
+	return false
+}
+
+func (b Backoff) rand() *rand.Rand {
+	return rand.New(rand.NewSource(b.Seed))
+}
+
+func (b Backoff) interval(retry int, rnd *rand.Rand) time.Duration {
+	if b.InitialInterval <= 0 {
+		return 0
+	}
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	interval := float64(b.InitialInterval) * math.Pow(multiplier, float64(retry))
+	if 0 < b.MaxInterval && float64(b.MaxInterval) < interval {
+		interval = float64(b.MaxInterval)
+	}
+	if 0 < b.Jitter {
+		jitter := math.Min(b.Jitter, 1)
+		interval = interval * (1 + jitter*(2*rnd.Float64()-1))
+	}
+	if math.MaxInt64 < interval {
+		return math.MaxInt64
+	}
+	return time.Duration(interval)
+}
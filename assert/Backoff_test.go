@@ -0,0 +1,138 @@
+package assert_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/clock"
+	"github.com/adamluzsi/testcase/clock/timecop"
+	"github.com/adamluzsi/testcase/internal/doubles"
+)
+
+var (
+	_ assert.RetryStrategy    = assert.Backoff{}
+	_ assert.RetryStrategyCtx = assert.Backoff{}
+)
+
+func TestBackoff_Intervals(t *testing.T) {
+	t.Run("the interval grows exponentially", func(t *testing.T) {
+		b := assert.Backoff{InitialInterval: time.Millisecond}
+		assert.Equal(t, []time.Duration{
+			time.Millisecond,
+			2 * time.Millisecond,
+			4 * time.Millisecond,
+			8 * time.Millisecond,
+		}, b.Intervals(4))
+	})
+	t.Run("the interval growth uses the multiplier", func(t *testing.T) {
+		b := assert.Backoff{InitialInterval: time.Millisecond, Multiplier: 3}
+		assert.Equal(t, []time.Duration{
+			time.Millisecond,
+			3 * time.Millisecond,
+			9 * time.Millisecond,
+		}, b.Intervals(3))
+	})
+	t.Run("the interval is capped with the max interval", func(t *testing.T) {
+		b := assert.Backoff{InitialInterval: time.Millisecond, MaxInterval: 3 * time.Millisecond}
+		assert.Equal(t, []time.Duration{
+			time.Millisecond,
+			2 * time.Millisecond,
+			3 * time.Millisecond,
+			3 * time.Millisecond,
+		}, b.Intervals(4))
+	})
+	t.Run("the interval can't overflow", func(t *testing.T) {
+		b := assert.Backoff{InitialInterval: time.Hour}
+		intervals := b.Intervals(128)
+		assert.True(t, 0 < intervals[len(intervals)-1])
+	})
+	t.Run("when jitter is set, the intervals are randomised within the jitter range", func(t *testing.T) {
+		b := assert.Backoff{InitialInterval: time.Second, Multiplier: 1, Jitter: 0.5, Seed: 42}
+		intervals := b.Intervals(64)
+		var hasDifferent bool
+		for _, interval := range intervals {
+			assert.True(t, 500*time.Millisecond <= interval && interval <= 1500*time.Millisecond)
+			if interval != time.Second {
+				hasDifferent = true
+			}
+		}
+		assert.True(t, hasDifferent)
+	})
+	t.Run("when jitter is set, the same seed yields the same intervals", func(t *testing.T) {
+		b := assert.Backoff{InitialInterval: time.Second, Jitter: 0.5, Seed: 42}
+		assert.Equal(t, b.Intervals(8), b.Intervals(8))
+		b2 := b
+		b2.Seed = 24
+		assert.NotEqual(t, b.Intervals(8), b2.Intervals(8))
+	})
+}
+
+func TestBackoff_While(t *testing.T) {
+	countAttempts := func(strategy assert.RetryStrategy, passAt int) int {
+		var attempts int
+		strategy.While(func() bool {
+			attempts++
+			return attempts != passAt
+		})
+		return attempts
+	}
+
+	t.Run("when no limit is defined, the condition is attempted once", func(t *testing.T) {
+		assert.Equal(t, 1, countAttempts(assert.Backoff{}, 0))
+	})
+	t.Run("when the condition is met, retrying stops", func(t *testing.T) {
+		assert.Equal(t, 3, countAttempts(assert.Backoff{Count: 42}, 3))
+	})
+	t.Run("when count is reached, retrying stops", func(t *testing.T) {
+		assert.Equal(t, 4, countAttempts(assert.Backoff{Count: 3}, 0))
+	})
+	t.Run("when timeout is reached, retrying stops", func(t *testing.T) {
+		b := assert.Backoff{InitialInterval: time.Millisecond, Multiplier: 1, Timeout: 25 * time.Millisecond}
+		start := time.Now()
+		attempts := countAttempts(b, 0)
+		assert.True(t, 1 < attempts)
+		assert.True(t, time.Since(start) < time.Second)
+	})
+	t.Run("when both count and timeout are set, the first reached limit stops the retrying", func(t *testing.T) {
+		assert.Equal(t, 3, countAttempts(assert.Backoff{Count: 2, Timeout: time.Minute}, 0))
+
+		start := time.Now()
+		attempts := countAttempts(assert.Backoff{InitialInterval: time.Millisecond, Multiplier: 1, Count: 1e6, Timeout: 25 * time.Millisecond}, 0)
+		assert.True(t, attempts < 1e6)
+		assert.True(t, time.Since(start) < time.Second)
+	})
+	t.Run("the waiting uses the clock package, thus it can be accelerated with timecop", func(t *testing.T) {
+		timecop.SetSpeed(t, 1000)
+		b := assert.Backoff{InitialInterval: time.Second, MaxInterval: 10 * time.Second, Timeout: time.Minute}
+		start := time.Now()
+		clockStart := clock.TimeNow()
+		attempts := countAttempts(b, 0)
+		assert.True(t, 1 < attempts)
+		assert.True(t, time.Minute <= clock.TimeNow().Sub(clockStart))
+		assert.True(t, time.Since(start) < 5*time.Second)
+	})
+	t.Run("when the context is cancelled, the waiting is interrupted", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 25*time.Millisecond)
+		defer cancel()
+		var attempts int
+		start := time.Now()
+		assert.Backoff{InitialInterval: time.Hour, Count: 42}.WhileCtx(ctx, func() bool {
+			attempts++
+			return true
+		})
+		assert.Equal(t, 1, attempts)
+		assert.True(t, time.Since(start) < time.Minute)
+	})
+	t.Run("with Eventually", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		var attempts int
+		assert.Eventually{RetryStrategy: assert.Backoff{InitialInterval: time.Millisecond, Count: 5}}.Assert(dtb, func(it assert.It) {
+			attempts++
+			it.Must.True(attempts == 3)
+		})
+		assert.False(t, dtb.IsFailed)
+		assert.Equal(t, 3, attempts)
+	})
+}
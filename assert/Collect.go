@@ -0,0 +1,136 @@
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/adamluzsi/testcase/internal"
+	"github.com/adamluzsi/testcase/internal/fmterror"
+)
+
+// Collect runs the assertion block as soft assertions.
+// The failed assertions of the block are not reported one by one,
+// but collected with their caller location, and reported together as a numbered summary at the end of the block.
+// The test is marked as failed once, and the test execution continues after the block.
+//
+// A failed Must assertion still stops the block, but the failures collected until then are reported.
+// When the test itself is stopped within the block, like with testing.TB#SkipNow or testing.TB#FailNow,
+// the collected failures are reported, and then the test is stopped as well.
+// Collect is useful to validate every field of a big structure, like an API response, in a single run.
+func Collect(tb testing.TB, blk func(it It)) {
+	tb.Helper()
+	ctb := &collectorTB{TB: tb}
+	out := internal.RecoverGoexit(func() {
+		tb.Helper()
+		blk(MakeIt(ctb))
+	})
+	ctb.Report()
+	if out.Goexit && !ctb.IsStopped() {
+		runtime.Goexit() // the block was not stopped by a collected failure, but by the test itself
+	}
+}
+
+// collectorTB is a testing.TB which collects the failures and their logs instead of reporting them.
+type collectorTB struct {
+	testing.TB
+
+	mutex    sync.Mutex
+	logs     []string
+	failures []blockResult
+	stopped  bool
+}
+
+func (c *collectorTB) Log(args ...any) {
+	c.TB.Helper()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.logs = append(c.logs, strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (c *collectorTB) Logf(format string, args ...any) {
+	c.TB.Helper()
+	c.Log(fmt.Sprintf(format, args...))
+}
+
+func (c *collectorTB) Error(args ...any) {
+	c.TB.Helper()
+	c.Log(args...)
+	c.Fail()
+}
+
+func (c *collectorTB) Errorf(format string, args ...any) {
+	c.TB.Helper()
+	c.Logf(format, args...)
+	c.Fail()
+}
+
+func (c *collectorTB) Fatal(args ...any) {
+	c.TB.Helper()
+	c.Log(args...)
+	c.FailNow()
+}
+
+func (c *collectorTB) Fatalf(format string, args ...any) {
+	c.TB.Helper()
+	c.Logf(format, args...)
+	c.FailNow()
+}
+
+// Fail records a failure with the logs written since the previous failure.
+func (c *collectorTB) Fail() {
+	location := callerLocation()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.failures = append(c.failures, blockResult{
+		Number:   len(c.failures) + 1,
+		Location: location,
+		Messages: c.logs,
+	})
+	c.logs = nil
+}
+
+func (c *collectorTB) FailNow() {
+	c.Fail()
+	c.mutex.Lock()
+	c.stopped = true
+	c.mutex.Unlock()
+	runtime.Goexit()
+}
+
+// IsStopped reports whether the block was stopped with the collector's FailNow.
+func (c *collectorTB) IsStopped() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.stopped
+}
+
+func (c *collectorTB) Failed() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return 0 < len(c.failures) || c.TB.Failed()
+}
+
+// Report forwards the logs which didn't belong to any failure,
+// and reports the collected failures as a single summary.
+func (c *collectorTB) Report() {
+	c.TB.Helper()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, log := range c.logs {
+		c.TB.Log(log)
+	}
+	if len(c.failures) == 0 {
+		return
+	}
+	summary := &strings.Builder{}
+	summary.WriteString(fmterror.Message{
+		Method: "Collect",
+		Cause:  fmt.Sprintf("%d assertion(s) failed.", len(c.failures)),
+	}.String())
+	formatBlockResults(summary, c.failures)
+	c.TB.Log(summary.String())
+	c.TB.Fail()
+}
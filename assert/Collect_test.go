@@ -0,0 +1,96 @@
+package assert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/doubles"
+	"github.com/adamluzsi/testcase/sandbox"
+)
+
+func TestCollect(t *testing.T) {
+	t.Run("when every assertion passes, then nothing is reported", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		assert.Collect(dtb, func(it assert.It) {
+			it.Should.True(true)
+			it.Must.Equal(42, 42)
+		})
+		assert.False(t, dtb.IsFailed)
+		assert.NotContain(t, dtb.Logs.String(), "[Collect]")
+	})
+	t.Run("when multiple assertions fail, then they are reported as one numbered summary", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		var continued bool
+		out := sandbox.Run(func() {
+			assert.Collect(dtb, func(it assert.It) {
+				it.Should.Equal("foo", "bar", "first")
+				it.Log("unrelated log")
+				it.Should.True(false, "second")
+				it.Should.True(true)
+			})
+			continued = true
+		})
+		assert.True(t, out.OK)
+		assert.True(t, continued, "test execution was expected to continue after the block")
+		assert.True(t, dtb.IsFailed)
+		logs := dtb.Logs.String()
+		assert.Contain(t, logs, "[Collect] 2 assertion(s) failed.")
+		assert.Contain(t, logs, "#1 Collect_test.go:")
+		assert.Contain(t, logs, "#2 Collect_test.go:")
+		assert.Contain(t, logs, "first")
+		assert.Contain(t, logs, "second")
+		assert.Contain(t, logs, "unrelated log")
+		assert.True(t, strings.Index(logs, "first") < strings.Index(logs, "second"))
+		assert.Equal(t, 1, strings.Count(logs, "[Collect]"))
+	})
+	t.Run("when Must assertion fails, then the block stops, but the collected failures are reported", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		var reached bool
+		assert.Collect(dtb, func(it assert.It) {
+			it.Should.True(false, "first")
+			it.Must.True(false, "second")
+			reached = true
+		})
+		assert.False(t, reached)
+		assert.True(t, dtb.IsFailed)
+		assert.Contain(t, dtb.Logs.String(), "[Collect] 2 assertion(s) failed.")
+	})
+	t.Run("Failed reports the failures of the block", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		assert.Collect(dtb, func(it assert.It) {
+			assert.False(t, it.Failed())
+			it.Should.True(false)
+			assert.True(t, it.Failed())
+		})
+	})
+	t.Run("when the test is skipped within the block, then the test execution stops after the report", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		var continued bool
+		out := sandbox.Run(func() {
+			assert.Collect(dtb, func(it assert.It) {
+				it.Should.True(false, "first")
+				it.SkipNow()
+			})
+			continued = true
+		})
+		assert.False(t, out.OK)
+		assert.True(t, out.Goexit)
+		assert.False(t, continued)
+		assert.True(t, dtb.IsSkipped)
+		assert.Contain(t, dtb.Logs.String(), "[Collect] 1 assertion(s) failed.")
+	})
+	t.Run("when the test is stopped with the original testing.TB within the block, then the test execution stops", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		var continued bool
+		out := sandbox.Run(func() {
+			assert.Collect(dtb, func(it assert.It) {
+				dtb.FailNow()
+			})
+			continued = true
+		})
+		assert.True(t, out.Goexit)
+		assert.False(t, continued)
+		assert.True(t, dtb.IsFailed)
+	})
+}
@@ -0,0 +1,260 @@
+package assert
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/adamluzsi/testcase/internal/reflects"
+)
+
+// EqualOption configures how Asserter.Equal and Asserter.ContainExactly compare values.
+// The options are passed along with the assertion message arguments,
+// and they are applied recursively to every nested value.
+//
+//	assert.Equal(t, expected, actual, assert.IgnoreFields("UpdatedAt"), "optional message")
+type EqualOption interface {
+	configureEqual(*equalConfig)
+}
+
+type equalOptionFunc func(*equalConfig)
+
+func (fn equalOptionFunc) configureEqual(c *equalConfig) { fn(c) }
+
+// IgnoreFields will skip the struct fields with the given names during the comparison,
+// regardless of how deep they are in the compared values.
+func IgnoreFields(names ...string) EqualOption {
+	return equalOptionFunc(func(c *equalConfig) {
+		if c.ignoredFields == nil {
+			c.ignoredFields = make(map[string]struct{})
+		}
+		for _, name := range names {
+			c.ignoredFields[name] = struct{}{}
+		}
+	})
+}
+
+// IgnoreUnexported will skip the unexported struct fields during the comparison.
+var IgnoreUnexported EqualOption = equalOptionFunc(func(c *equalConfig) {
+	c.ignoreUnexported = true
+})
+
+// EquateEmpty will consider a nil and an empty slice or map as equal.
+var EquateEmpty EqualOption = equalOptionFunc(func(c *equalConfig) {
+	c.equateEmpty = true
+})
+
+// FloatTolerance will consider two floating point numbers equal
+// when their difference is not bigger than the tolerance.
+func FloatTolerance(eps float64) EqualOption {
+	return equalOptionFunc(func(c *equalConfig) {
+		c.floatTolerance = math.Abs(eps)
+	})
+}
+
+// Comparer defines the equality check for the values of type T.
+// It takes precedence over the IsEqual method of T.
+func Comparer[T any](fn func(a, b T) bool) EqualOption {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	return equalOptionFunc(func(c *equalConfig) {
+		if c.comparers == nil {
+			c.comparers = make(map[reflect.Type]func(a, b any) bool)
+		}
+		c.comparers[typ] = func(a, b any) bool {
+			return fn(a.(T), b.(T))
+		}
+	})
+}
+
+type equalConfig struct {
+	ignoredFields    map[string]struct{}
+	ignoreUnexported bool
+	equateEmpty      bool
+	floatTolerance   float64
+	comparers        map[reflect.Type]func(a, b any) bool
+}
+
+// splitEqualOptions separates the EqualOption values from the assertion message arguments.
+func splitEqualOptions(msg []any) ([]EqualOption, []any) {
+	var (
+		opts []EqualOption
+		rest = make([]any, 0, len(msg))
+	)
+	for _, m := range msg {
+		if opt, ok := m.(EqualOption); ok {
+			opts = append(opts, opt)
+			continue
+		}
+		rest = append(rest, m)
+	}
+	return opts, rest
+}
+
+// equality is the comparison engine behind the equality based assertions.
+// Without options, it has the same semantics as reflect.DeepEqual,
+// except that the IsEqual methods of the nested values are also taken into account.
+type equality struct {
+	asserter Asserter
+	config   equalConfig
+	visited  map[equalityVisit]struct{}
+}
+
+// equalityVisit is used to detect cyclic references,
+// like reflect.DeepEqual does.
+type equalityVisit struct {
+	exp, act uintptr
+	typ      reflect.Type
+}
+
+func newEquality(a Asserter, opts []EqualOption) *equality {
+	e := &equality{asserter: a, visited: make(map[equalityVisit]struct{})}
+	for _, opt := range opts {
+		opt.configureEqual(&e.config)
+	}
+	return e
+}
+
+func (e *equality) IsEqual(exp, act any) bool {
+	return e.isEqual(reflect.ValueOf(exp), reflect.ValueOf(act))
+}
+
+func (e *equality) isEqual(exp, act reflect.Value) bool {
+	if !exp.IsValid() || !act.IsValid() {
+		return exp.IsValid() == act.IsValid()
+	}
+	if exp.Type() != act.Type() {
+		return false
+	}
+	if isEqual, ok := e.tryCustom(exp, act); ok {
+		return isEqual
+	}
+	if e.isVisited(exp, act) {
+		return true
+	}
+	switch exp.Kind() {
+	case reflect.Struct:
+		return e.isEqualStruct(exp, act)
+	case reflect.Slice:
+		if exp.IsNil() != act.IsNil() && !(e.config.equateEmpty && exp.Len() == 0 && act.Len() == 0) {
+			return false
+		}
+		if exp.Len() != act.Len() {
+			return false
+		}
+		if exp.Pointer() == act.Pointer() {
+			return true
+		}
+		return e.isEqualElements(exp, act)
+	case reflect.Array:
+		return e.isEqualElements(exp, act)
+	case reflect.Map:
+		if exp.IsNil() != act.IsNil() && !(e.config.equateEmpty && exp.Len() == 0 && act.Len() == 0) {
+			return false
+		}
+		if exp.Len() != act.Len() {
+			return false
+		}
+		if exp.Pointer() == act.Pointer() {
+			return true
+		}
+		for _, key := range exp.MapKeys() {
+			actValue := act.MapIndex(key)
+			if !actValue.IsValid() || !e.isEqual(exp.MapIndex(key), actValue) {
+				return false
+			}
+		}
+		return true
+	case reflect.Pointer:
+		if exp.Pointer() == act.Pointer() {
+			return true
+		}
+		return e.isEqual(exp.Elem(), act.Elem())
+	case reflect.Interface:
+		if exp.IsNil() || act.IsNil() {
+			return exp.IsNil() == act.IsNil()
+		}
+		return e.isEqual(exp.Elem(), act.Elem())
+	case reflect.Func:
+		return exp.IsNil() && act.IsNil()
+	case reflect.Float32, reflect.Float64:
+		if e.config.floatTolerance != 0 {
+			return math.Abs(exp.Float()-act.Float()) <= e.config.floatTolerance
+		}
+		return exp.Float() == act.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return exp.Complex() == act.Complex()
+	case reflect.Bool:
+		return exp.Bool() == act.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return exp.Int() == act.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return exp.Uint() == act.Uint()
+	case reflect.String:
+		return exp.String() == act.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return exp.Pointer() == act.Pointer()
+	default:
+		return false
+	}
+}
+
+// tryCustom checks the equality with the registered Comparer or with the IsEqual method of the value's type.
+func (e *equality) tryCustom(exp, act reflect.Value) (isEqual bool, ok bool) {
+	exp, act = reflects.Accessible(exp), reflects.Accessible(act)
+	if !exp.CanInterface() || !act.CanInterface() {
+		return false, false
+	}
+	if fn, ok := e.config.comparers[exp.Type()]; ok {
+		return fn(exp.Interface(), act.Interface()), true
+	}
+	if _, ok := exp.Type().MethodByName("IsEqual"); !ok {
+		return false, false
+	}
+	return e.asserter.tryIsEqual(exp.Interface(), act.Interface())
+}
+
+func (e *equality) isVisited(exp, act reflect.Value) bool {
+	switch exp.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Pointer:
+		if exp.IsNil() || act.IsNil() {
+			return false
+		}
+	default:
+		return false
+	}
+	v := equalityVisit{exp: exp.Pointer(), act: act.Pointer(), typ: exp.Type()}
+	if _, ok := e.visited[v]; ok {
+		return true
+	}
+	e.visited[v] = struct{}{}
+	return false
+}
+
+func (e *equality) isEqualStruct(exp, act reflect.Value) bool {
+	if exp.CanInterface() && act.CanInterface() {
+		// fields of an addressable struct can be accessed even when they are unexported
+		exp, act = reflects.Addressable(exp), reflects.Addressable(act)
+	}
+	typ := exp.Type()
+	for i, n := 0, typ.NumField(); i < n; i++ {
+		field := typ.Field(i)
+		if _, ok := e.config.ignoredFields[field.Name]; ok {
+			continue
+		}
+		if e.config.ignoreUnexported && !field.IsExported() {
+			continue
+		}
+		if !e.isEqual(exp.Field(i), act.Field(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *equality) isEqualElements(exp, act reflect.Value) bool {
+	for i, n := 0, exp.Len(); i < n; i++ {
+		if !e.isEqual(exp.Index(i), act.Index(i)) {
+			return false
+		}
+	}
+	return true
+}
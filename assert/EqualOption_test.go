@@ -0,0 +1,171 @@
+package assert_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/doubles"
+)
+
+type EqualOptionExampleRecord struct {
+	ID        string
+	Price     float64
+	Tags      []string
+	Labels    map[string]string
+	UpdatedAt time.Time
+	Items     []EqualOptionExampleItem
+	secret    string
+}
+
+type EqualOptionExampleItem struct {
+	Name      string
+	UpdatedAt time.Time
+}
+
+func TestAsserter_Equal_options(t *testing.T) {
+	now := time.Now()
+	record := EqualOptionExampleRecord{
+		ID:        "42",
+		Price:     10.1,
+		Tags:      []string{},
+		Labels:    map[string]string{},
+		UpdatedAt: now,
+		Items:     []EqualOptionExampleItem{{Name: "foo", UpdatedAt: now}},
+		secret:    "foo",
+	}
+	modify := func(fn func(r *EqualOptionExampleRecord)) EqualOptionExampleRecord {
+		r := record
+		r.Items = append([]EqualOptionExampleItem{}, record.Items...)
+		fn(&r)
+		return r
+	}
+
+	type TestCase struct {
+		Actual   any
+		Options  []any
+		IsFailed bool
+	}
+	for name, tc := range map[string]TestCase{
+		"without options, values are compared deeply": {
+			Actual: modify(func(r *EqualOptionExampleRecord) {}),
+		},
+		"without options, a different nested field fails the assertion": {
+			Actual:   modify(func(r *EqualOptionExampleRecord) { r.Items[0].UpdatedAt = now.Add(time.Second) }),
+			IsFailed: true,
+		},
+		"IgnoreFields skips the named fields at every level": {
+			Actual: modify(func(r *EqualOptionExampleRecord) {
+				r.UpdatedAt = now.Add(time.Hour)
+				r.Items[0].UpdatedAt = now.Add(time.Second)
+			}),
+			Options: []any{assert.IgnoreFields("UpdatedAt")},
+		},
+		"IgnoreFields doesn't skip the other fields": {
+			Actual:   modify(func(r *EqualOptionExampleRecord) { r.Items[0].Name = "bar" }),
+			Options:  []any{assert.IgnoreFields("UpdatedAt")},
+			IsFailed: true,
+		},
+		"without IgnoreUnexported, unexported fields are compared": {
+			Actual:   modify(func(r *EqualOptionExampleRecord) { r.secret = "bar" }),
+			IsFailed: true,
+		},
+		"IgnoreUnexported skips the unexported fields": {
+			Actual:  modify(func(r *EqualOptionExampleRecord) { r.secret = "bar" }),
+			Options: []any{assert.IgnoreUnexported},
+		},
+		"Comparer defines the equality of a type": {
+			Actual: modify(func(r *EqualOptionExampleRecord) {
+				r.UpdatedAt = now.Add(time.Millisecond)
+				r.Items[0].UpdatedAt = now.Add(time.Millisecond)
+			}),
+			Options: []any{assert.Comparer(func(a, b time.Time) bool {
+				return a.Truncate(time.Second).Equal(b.Truncate(time.Second))
+			})},
+		},
+		"FloatTolerance accepts difference within the tolerance": {
+			Actual:  modify(func(r *EqualOptionExampleRecord) { r.Price = 10.1 + 1e-9 }),
+			Options: []any{assert.FloatTolerance(1e-6)},
+		},
+		"FloatTolerance rejects difference beyond the tolerance": {
+			Actual:   modify(func(r *EqualOptionExampleRecord) { r.Price = 10.2 }),
+			Options:  []any{assert.FloatTolerance(1e-6)},
+			IsFailed: true,
+		},
+		"without EquateEmpty, nil and empty slices are different": {
+			Actual:   modify(func(r *EqualOptionExampleRecord) { r.Tags = nil }),
+			IsFailed: true,
+		},
+		"EquateEmpty makes nil and empty slices and maps equal": {
+			Actual: modify(func(r *EqualOptionExampleRecord) {
+				r.Tags = nil
+				r.Labels = nil
+			}),
+			Options: []any{assert.EquateEmpty},
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			dtb := &doubles.TB{}
+			asserter(dtb).Equal(record, tc.Actual, append(tc.Options, "custom message")...)
+			assert.Equal(t, tc.IsFailed, dtb.IsFailed)
+			if tc.IsFailed {
+				assert.Contain(t, dtb.Logs.String(), "custom message")
+			}
+		})
+	}
+}
+
+func TestAsserter_Equal_options_messageExcludesOptions(t *testing.T) {
+	dtb := &doubles.TB{}
+	asserter(dtb).Equal(1.0, 2.0, assert.FloatTolerance(0.1), "custom message")
+	assert.True(t, dtb.IsFailed)
+	assert.Contain(t, dtb.Logs.String(), "custom message")
+	assert.False(t, strings.Contains(dtb.Logs.String(), "equalOptionFunc"))
+}
+
+func TestAsserter_Equal_nestedIsEqual(t *testing.T) {
+	type T struct{ V []ExampleEqualable }
+	exp := T{V: []ExampleEqualable{{relevantUnexportedValue: 42, IrrelevantExportedField: 1}}}
+	act := T{V: []ExampleEqualable{{relevantUnexportedValue: 42, IrrelevantExportedField: 2}}}
+	assert.Equal(t, exp, act)
+}
+
+func TestAsserter_Equal_cyclicReference(t *testing.T) {
+	type Node struct {
+		Next *Node
+		V    int
+	}
+	a := &Node{V: 1}
+	a.Next = a
+	b := &Node{V: 1}
+	b.Next = b
+	assert.Equal(t, a, b)
+}
+
+func TestAsserter_Equal_NaN(t *testing.T) {
+	dtb := &doubles.TB{}
+	asserter(dtb).Equal(math.NaN(), math.NaN())
+	assert.True(t, dtb.IsFailed, "NaN should not be equal to NaN, like with reflect.DeepEqual")
+}
+
+func TestAsserter_ContainExactly_options(t *testing.T) {
+	now := time.Now()
+	exp := []EqualOptionExampleItem{{Name: "foo", UpdatedAt: now}, {Name: "bar", UpdatedAt: now}}
+	act := []EqualOptionExampleItem{{Name: "bar", UpdatedAt: now.Add(time.Hour)}, {Name: "foo"}}
+
+	dtb := &doubles.TB{}
+	asserter(dtb).ContainExactly(exp, act)
+	assert.True(t, dtb.IsFailed)
+
+	dtb = &doubles.TB{}
+	asserter(dtb).ContainExactly(exp, act, assert.IgnoreFields("UpdatedAt"))
+	assert.False(t, dtb.IsFailed)
+
+	assert.ContainExactly(t,
+		map[string][]string{"foo": nil},
+		map[string][]string{"foo": {}},
+		assert.EquateEmpty)
+}
@@ -0,0 +1,34 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/adamluzsi/testcase/internal/fmterror"
+)
+
+// ErrorAs asserts that the error's wrap chain has an error which is assignable to T,
+// and returns it for further assertions.
+// T must be an interface, or a type which implements the error interface.
+// When there is no such error in the chain, the test fails immediately, like with Must.
+//
+//	var vErr *ValidationError = assert.ErrorAs[*ValidationError](tb, err)
+//	assert.Equal(tb, "email", vErr.Field)
+func ErrorAs[T any](tb testing.TB, err error, msg ...any) T {
+	tb.Helper()
+	var target T
+	if err != nil && errors.As(err, &target) {
+		return target
+	}
+	Must(tb).fnWithDetails(fmterror.Message{
+		Method:  "ErrorAs",
+		Cause:   fmt.Sprintf("The error chain has no %s error.", reflect.TypeOf((*T)(nil)).Elem().String()),
+		Message: msg,
+		Values: []fmterror.Value{
+			{Label: "error", Value: errorMessage(err)},
+		},
+	}, errorChain(err))
+	return target
+}
@@ -0,0 +1,68 @@
+package assert_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/doubles"
+	"github.com/adamluzsi/testcase/sandbox"
+)
+
+type ErrorAsValidationError struct{ Field string }
+
+func (err *ErrorAsValidationError) Error() string {
+	return fmt.Sprintf("invalid %s", err.Field)
+}
+
+type ErrorAsMulti []error
+
+func (errs ErrorAsMulti) Error() string   { return "multi" }
+func (errs ErrorAsMulti) Unwrap() []error { return errs }
+
+func TestErrorAs(t *testing.T) {
+	t.Run("when the error chain has an error of the type, then it is returned", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		err := fmt.Errorf("create user: %w", &ErrorAsValidationError{Field: "email"})
+		vErr := assert.ErrorAs[*ErrorAsValidationError](dtb, err)
+		assert.False(t, dtb.IsFailed)
+		assert.NotNil(t, vErr)
+		assert.Equal(t, "email", vErr.Field)
+	})
+	t.Run("when the error chain has a multi error, then its errors are checked as well", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		err := fmt.Errorf("batch: %w", ErrorAsMulti{errors.New("foo"), &ErrorAsValidationError{Field: "name"}})
+		vErr := assert.ErrorAs[*ErrorAsValidationError](dtb, err)
+		assert.False(t, dtb.IsFailed)
+		assert.Equal(t, "name", vErr.Field)
+	})
+	t.Run("when the target type is an interface, then the matching error is returned", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		err := fmt.Errorf("wrap: %w", &ErrorAsValidationError{Field: "age"})
+		fErr := assert.ErrorAs[interface{ Error() string }](dtb, err)
+		assert.False(t, dtb.IsFailed)
+		assert.Equal(t, err.Error(), fErr.Error())
+	})
+	t.Run("when the error chain has no error of the type, then it fails immediately with the error chain", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		err := fmt.Errorf("create user: %w", errors.New("boom"))
+		out := sandbox.Run(func() {
+			assert.ErrorAs[*ErrorAsValidationError](dtb, err, "msg")
+		})
+		assert.False(t, out.OK)
+		assert.True(t, dtb.IsFailed)
+		logs := dtb.Logs.String()
+		assert.Contain(t, logs, "[ErrorAs] The error chain has no *assert_test.ErrorAsValidationError error.")
+		assert.Contain(t, logs, "msg")
+		assert.Contain(t, logs, `*errors.errorString "boom"`)
+	})
+	t.Run("when the error is nil, then it fails", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		out := sandbox.Run(func() {
+			assert.ErrorAs[*ErrorAsValidationError](dtb, nil)
+		})
+		assert.False(t, out.OK)
+		assert.True(t, dtb.IsFailed)
+	})
+}
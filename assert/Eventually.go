@@ -1,11 +1,15 @@
 package assert
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/adamluzsi/testcase/clock"
 	"github.com/adamluzsi/testcase/internal"
 	"github.com/adamluzsi/testcase/internal/doubles"
+	"github.com/adamluzsi/testcase/internal/fmterror"
 )
 
 func EventuallyWithin[T time.Duration | int](durationOrCount T) Eventually {
@@ -25,7 +29,14 @@ func EventuallyWithin[T time.Duration | int](durationOrCount T) Eventually {
 // A common scenario where using Eventually will benefit you is testing concurrent operations.
 // Due to the nature of async operations, one might need to wait
 // and observe the system with multiple tries before the outcome can be seen.
-type Eventually struct{ RetryStrategy RetryStrategy }
+type Eventually struct {
+	RetryStrategy RetryStrategy
+	// History is the number of the most recent failed attempts kept in the attempt history.
+	// When the assertion eventually fails, the history is reported as a compact timeline,
+	// which helps to understand conditions that oscillate between the attempts.
+	// When History is zero, no attempt history is reported.
+	History int
+}
 
 type RetryStrategy interface {
 	// While implements the retry strategy looping part.
@@ -34,6 +45,14 @@ type RetryStrategy interface {
 	While(condition func() bool)
 }
 
+// RetryStrategyCtx is an optional interface of a RetryStrategy,
+// which allows the retrying to be cancelled with a context.
+type RetryStrategyCtx interface {
+	// WhileCtx implements the retry strategy looping part, like While,
+	// but stops the retrying when the context is cancelled.
+	WhileCtx(ctx context.Context, condition func() bool)
+}
+
 type RetryStrategyFunc func(condition func() bool)
 
 func (fn RetryStrategyFunc) While(condition func() bool) { fn(condition) }
@@ -44,26 +63,85 @@ func (fn RetryStrategyFunc) While(condition func() bool) { fn(condition) }
 // Calling multiple times the assertion function block content should be a safe and repeatable operation.
 func (r Eventually) Assert(tb testing.TB, blk func(it It)) {
 	tb.Helper()
-	var lastRecorder *doubles.RecorderTB
+	r.assert(context.Background(), tb, blk)
+}
 
-	r.RetryStrategy.While(func() bool {
+// AssertCtx is like Assert, but it stops retrying when the context is cancelled,
+// for example, when the deadline of the test's context is reached.
+// On cancellation, the cancellation cause is reported alongside the last failed assertion results.
+// If the context is cancelled before any attempt could have been made, the assertion fails.
+//
+// When the RetryStrategy implements RetryStrategyCtx, the cancellation also interrupts the waiting between the attempts,
+// otherwise no further attempt is made after the cancellation.
+func (r Eventually) AssertCtx(ctx context.Context, tb testing.TB, blk func(it It)) {
+	tb.Helper()
+	r.assert(ctx, tb, blk)
+}
+
+func (r Eventually) assert(ctx context.Context, tb testing.TB, blk func(it It)) {
+	tb.Helper()
+	var (
+		lastRecorder *doubles.RecorderTB
+		history      = attemptHistory{Size: r.History}
+		attempt      int
+	)
+
+	r.while(ctx, func() bool {
 		tb.Helper()
+		attempt++
 		lastRecorder = &doubles.RecorderTB{TB: tb}
+		startedAt := clock.TimeNow()
 		internal.RecoverGoexit(func() {
 			tb.Helper()
-			blk(MakeIt(lastRecorder))
+			it := MakeIt(lastRecorder)
+			it.Attempt = attempt
+			blk(it)
 		})
 		if lastRecorder.IsFailed {
+			history.Add(attemptRecord{
+				Attempt:   attempt,
+				StartedAt: startedAt,
+				Duration:  clock.TimeNow().Sub(startedAt),
+				Messages:  lastRecorder.Messages(),
+			})
 			lastRecorder.CleanupNow()
 		}
 		return lastRecorder.IsFailed
 	})
 
+	if lastRecorder == nil || lastRecorder.IsFailed {
+		if 0 < r.History && 0 < attempt {
+			tb.Log(history.String())
+		}
+		if err := ctx.Err(); err != nil {
+			tb.Log(fmterror.Message{
+				Method: "Eventually",
+				Cause:  fmt.Sprintf("Retrying was cancelled after %d attempt(s): %v", attempt, err),
+			}.String())
+			if lastRecorder == nil {
+				tb.Fail()
+			}
+		}
+	}
 	if lastRecorder != nil {
 		lastRecorder.Forward()
 	}
 }
 
+func (r Eventually) while(ctx context.Context, condition func() bool) {
+	if ctx.Done() == nil { // the context can't be cancelled
+		r.RetryStrategy.While(condition)
+		return
+	}
+	if strategy, ok := r.RetryStrategy.(RetryStrategyCtx); ok {
+		strategy.WhileCtx(ctx, condition)
+		return
+	}
+	r.RetryStrategy.While(func() bool {
+		return ctx.Err() == nil && condition()
+	})
+}
+
 func RetryCount(times int) RetryStrategy {
 	return RetryStrategyFunc(func(condition func() bool) {
 		for i := 0; i < times+1; i++ {
@@ -1,6 +1,7 @@
 package assert_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -448,6 +449,112 @@ func TestRetryCount_While(t *testing.T) {
 	})
 }
 
+func TestEventually_Assert_attempt(t *testing.T) {
+	dtb := &doubles.TB{}
+	var attempts []int
+	assert.Eventually{RetryStrategy: assert.RetryCount(5)}.Assert(dtb, func(it assert.It) {
+		attempts = append(attempts, it.Attempt)
+		it.Must.True(it.Attempt == 3)
+	})
+	assert.False(t, dtb.IsFailed)
+	assert.Equal(t, []int{1, 2, 3}, attempts)
+}
+
+func TestEventually_Assert_history(t *testing.T) {
+	t.Run("when history is not set, only the last attempt is reported", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		sandbox.Run(func() {
+			assert.Eventually{RetryStrategy: assert.RetryCount(2)}.Assert(dtb, func(it assert.It) {
+				it.Must.True(false, "attempt", it.Attempt)
+			})
+		})
+		assert.True(t, dtb.IsFailed)
+		assert.NotContain(t, dtb.Logs.String(), "attempt history")
+		assert.NotContain(t, dtb.Logs.String(), "attempt 1")
+		assert.Contain(t, dtb.Logs.String(), "attempt 3")
+	})
+	t.Run("when history is set, the last failed attempts are reported as a timeline", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		sandbox.Run(func() {
+			assert.Eventually{RetryStrategy: assert.RetryCount(4), History: 3}.Assert(dtb, func(it assert.It) {
+				it.Log("log of", it.Attempt)
+				it.Must.True(false, "attempt", it.Attempt)
+			})
+		})
+		assert.True(t, dtb.IsFailed)
+		logs := dtb.Logs.String()
+		assert.Contain(t, logs, "[Eventually] attempt history (last 3 of 5 failed attempts)")
+		assert.NotContain(t, logs, "#2\t")
+		assert.Contain(t, logs, "#3\t+0s\ttook ")
+		assert.Contain(t, logs, `log of 3; [True] "true" was expected. attempt 3 value: false`)
+		assert.Contain(t, logs, "#4\t")
+		assert.Contain(t, logs, "#5\t")
+	})
+	t.Run("when the assertion eventually passes, the history is not reported", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		assert.Eventually{RetryStrategy: assert.RetryCount(4), History: 3}.Assert(dtb, func(it assert.It) {
+			it.Must.True(it.Attempt == 2)
+		})
+		assert.False(t, dtb.IsFailed)
+		assert.NotContain(t, dtb.Logs.String(), "attempt history")
+	})
+}
+
+func TestEventually_AssertCtx(t *testing.T) {
+	t.Run("when the context is not cancelled, it behaves like Assert", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		assert.Eventually{RetryStrategy: assert.RetryCount(5)}.AssertCtx(context.Background(), dtb, func(it assert.It) {
+			it.Must.True(it.Attempt == 3)
+		})
+		assert.False(t, dtb.IsFailed)
+	})
+	t.Run("when the context is cancelled before the first attempt, then it fails without attempting", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var ran bool
+		assert.Eventually{RetryStrategy: assert.Waiter{Timeout: time.Hour}}.AssertCtx(ctx, dtb, func(it assert.It) {
+			ran = true
+		})
+		assert.False(t, ran)
+		assert.True(t, dtb.IsFailed)
+		assert.Contain(t, dtb.Logs.String(), "Retrying was cancelled after 0 attempt(s): context canceled")
+	})
+	t.Run("when the context deadline is reached, retrying stops, and the cause is reported with the last failure", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		sandbox.Run(func() {
+			assert.Eventually{RetryStrategy: assert.Waiter{WaitDuration: time.Millisecond, Timeout: time.Hour}}.AssertCtx(ctx, dtb, func(it assert.It) {
+				it.Must.True(false, "the last failure")
+			})
+		})
+		assert.True(t, time.Since(start) < time.Minute)
+		assert.True(t, dtb.IsFailed)
+		assert.Contain(t, dtb.Logs.String(), "context deadline exceeded")
+		assert.Contain(t, dtb.Logs.String(), "the last failure")
+	})
+	t.Run("when the retry strategy is not context aware, no further attempt is made after the cancellation", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		ctx, cancel := context.WithCancel(context.Background())
+		strategy := assert.RetryStrategyFunc(func(condition func() bool) {
+			for condition() {
+			}
+		})
+		sandbox.Run(func() {
+			assert.Eventually{RetryStrategy: strategy}.AssertCtx(ctx, dtb, func(it assert.It) {
+				if it.Attempt == 3 {
+					cancel()
+				}
+				it.Must.True(false)
+			})
+		})
+		assert.True(t, dtb.IsFailed)
+		assert.Contain(t, dtb.Logs.String(), "Retrying was cancelled after 3 attempt(s): context canceled")
+	})
+}
+
 func TestEventuallyWithin(t *testing.T) {
 	t.Run("time.Duration", func(t *testing.T) {
 		t.Run("on timeout", func(t *testing.T) {
@@ -0,0 +1,60 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase/internal/fmterror"
+)
+
+// ExactlyOneOf is an assertion helper that allows you run ExactlyOneOf.Test assertion blocks,
+// where exactly one of them must succeed.
+// When none of the blocks pass, Finish reports every failure,
+// and when more than one of them pass, Finish reports the passing ones.
+// common usage use-cases:
+//   - list of structures, where exactly one of them is expected to match, like a unique entity.
+//   - fan out scenario, where you need to check that exactly one of the workers received the event.
+type ExactlyOneOf struct {
+	TB   testing.TB
+	Fail func()
+
+	results blockResults
+}
+
+// Test will test a block of assertion, where exactly one of the blocks must succeed in order to make ExactlyOneOf pass.
+// You can have as much ExactlyOneOf.Test calls as you need, and all of them are executed.
+// Using Test is safe for concurrently.
+func (eo *ExactlyOneOf) Test(blk func(it It)) {
+	eo.TB.Helper()
+	eo.results.Run(eo.TB, blk)
+}
+
+// Finish will check if exactly one of the assertions succeeded.
+func (eo *ExactlyOneOf) Finish(msg ...interface{}) {
+	eo.TB.Helper()
+	passed := eo.results.Filter(true)
+	if len(passed) == 1 {
+		return
+	}
+	var (
+		cause   string
+		results []blockResult
+	)
+	if len(passed) == 0 {
+		cause = "None of the .Test succeeded"
+		results = eo.results.Filter(false)
+	} else {
+		cause = fmt.Sprintf("%d of the %d .Test succeeded, while exactly one expected to", len(passed), eo.results.Total())
+		results = passed
+	}
+	summary := &strings.Builder{}
+	summary.WriteString(fmterror.Message{
+		Method:  "ExactlyOneOf",
+		Cause:   cause,
+		Message: msg,
+	}.String())
+	formatBlockResults(summary, results)
+	eo.TB.Log(summary.String())
+	eo.Fail()
+}
@@ -0,0 +1,66 @@
+package assert_test
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/doubles"
+)
+
+func TestExactlyOneOf(t *testing.T) {
+	t.Run("when exactly one .Test passes, ExactlyOneOf yields no failure on .Finish", func(t *testing.T) {
+		stub := &doubles.TB{}
+		exactlyOneOf := &assert.ExactlyOneOf{TB: stub, Fail: stub.Fail}
+		exactlyOneOf.Test(func(it assert.It) { it.Must.True(false) })
+		exactlyOneOf.Test(func(it assert.It) {})
+		exactlyOneOf.Test(func(it assert.It) { it.Must.True(false) })
+		exactlyOneOf.Finish()
+		assert.False(t, stub.IsFailed)
+	})
+	t.Run("when none of the .Test passes, ExactlyOneOf yields failure on .Finish with every failure", func(t *testing.T) {
+		stub := &doubles.TB{}
+		exactlyOneOf := &assert.ExactlyOneOf{TB: stub, Fail: stub.Fail}
+		exactlyOneOf.Test(func(it assert.It) { it.Must.True(false, "first failure") })
+		exactlyOneOf.Test(func(it assert.It) { it.Must.True(false, "second failure") })
+		exactlyOneOf.Finish()
+		assert.True(t, stub.IsFailed)
+		logs := stub.Logs.String()
+		assert.Contain(t, logs, "[ExactlyOneOf] None of the .Test succeeded")
+		assert.Contain(t, logs, "first failure")
+		assert.Contain(t, logs, "second failure")
+	})
+	t.Run("when more than one .Test passes, ExactlyOneOf yields failure on .Finish, and reports the passing ones", func(t *testing.T) {
+		stub := &doubles.TB{}
+		exactlyOneOf := &assert.ExactlyOneOf{TB: stub, Fail: stub.Fail}
+		exactlyOneOf.Test(func(it assert.It) {})
+		exactlyOneOf.Test(func(it assert.It) { it.Must.True(false) })
+		exactlyOneOf.Test(func(it assert.It) {})
+		exactlyOneOf.Finish("msg")
+		assert.True(t, stub.IsFailed)
+		logs := stub.Logs.String()
+		assert.Contain(t, logs, "[ExactlyOneOf] 2 of the 3 .Test succeeded, while exactly one expected to")
+		assert.Contain(t, logs, "msg")
+		assert.Contain(t, logs, "#1 ExactlyOneOf_test.go:")
+		assert.Contain(t, logs, "#3 ExactlyOneOf_test.go:")
+		assert.NotContain(t, logs, "#2 ")
+	})
+	t.Run("when no .Test is made, ExactlyOneOf yields failure on .Finish", func(t *testing.T) {
+		stub := &doubles.TB{}
+		exactlyOneOf := &assert.ExactlyOneOf{TB: stub, Fail: stub.Fail}
+		exactlyOneOf.Finish()
+		assert.True(t, stub.IsFailed)
+	})
+}
+
+func TestExactlyOneOf_Test_race(t *testing.T) {
+	stub := &doubles.TB{}
+	exactlyOneOf := &assert.ExactlyOneOf{TB: stub, Fail: stub.Fail}
+	testcase.Race(func() {
+		exactlyOneOf.Test(func(it assert.It) {})
+	}, func() {
+		exactlyOneOf.Test(func(it assert.It) { it.Must.True(false) })
+	}, func() {
+		exactlyOneOf.Finish()
+	})
+}
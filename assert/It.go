@@ -18,4 +18,7 @@ type It struct {
 	// Should Asserter's will allow to continue the test scenario,
 	// but mark test failed on a failed assertion.
 	Should Asserter
+	// Attempt is the sequence number of the current attempt, starting from 1, when It is used in Eventually.
+	// Outside of Eventually, it is zero.
+	Attempt int
 }
@@ -0,0 +1,457 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/adamluzsi/testcase/internal/fmterror"
+	"github.com/adamluzsi/testcase/internal/reflects"
+	"github.com/adamluzsi/testcase/pp"
+)
+
+// Matcher is a partial expectation, which can be embedded into the expected value of Asserter.Match.
+type Matcher interface {
+	// Match returns an error that describes why the actual value doesn't match the expectation.
+	Match(actual any) error
+}
+
+// MatcherFunc is a function that implements the Matcher interface.
+type MatcherFunc func(actual any) error
+
+func (fn MatcherFunc) Match(actual any) error { return fn(actual) }
+
+// Fields is a partial expectation for a struct.
+// Only the listed fields are compared with the actual struct's fields,
+// and the field values can be Matchers as well.
+//
+//	assert.Match(tb, assert.Fields{
+//		"ID":        assert.Any[string](),
+//		"Name":      "John",
+//		"CreatedAt": assert.Between(before, after),
+//	}, user)
+type Fields map[string]any
+
+// Match allows you to match a value against an expectation, which can embed Matchers at any depth.
+// Fields of a struct can be partially matched with Fields.
+// Maps, slices and arrays are matched element by element,
+// while every other value is matched with equality.
+// Values of different basic types, like an untyped constant and a named int type, are considered equal,
+// when they belong to the same kind family, and the conversion between them doesn't lose information.
+//
+// On failure, the exact path of each mismatching value is reported.
+func (a Asserter) Match(expected, actual any, msg ...any) {
+	a.TB.Helper()
+	mismatches := newMatching().match(`actual`, expected, actual)
+	if len(mismatches) == 0 {
+		return
+	}
+	var lines []string
+	for _, m := range mismatches {
+		lines = append(lines, m.String())
+	}
+	a.fnWithDetails(fmterror.Message{
+		Method:  "Match",
+		Cause:   "Value doesn't match the expectation.",
+		Message: msg,
+	}, strings.Join(lines, "\n")+"\n")
+}
+
+type mismatch struct {
+	Path  string
+	Cause string
+}
+
+func (m mismatch) String() string {
+	return fmt.Sprintf("%s: %s", m.Path, m.Cause)
+}
+
+func isMatching(expected, actual any) bool {
+	return len(newMatching().match(`actual`, expected, actual)) == 0
+}
+
+// matching walks the expected and the actual values together.
+type matching struct {
+	visited map[matchingVisit]struct{}
+}
+
+// matchingVisit is used to detect cyclic references, like equalityVisit.
+type matchingVisit struct {
+	exp, act uintptr
+	typ      reflect.Type
+}
+
+func newMatching() *matching {
+	return &matching{visited: make(map[matchingVisit]struct{})}
+}
+
+func (m *matching) match(path string, expected, actual any) []mismatch {
+	if m, ok := expected.(Matcher); ok {
+		if err := m.Match(actual); err != nil {
+			return []mismatch{{Path: path, Cause: err.Error()}}
+		}
+		return nil
+	}
+	if fields, ok := expected.(Fields); ok {
+		return m.matchFields(path, fields, actual)
+	}
+	if expected == nil || actual == nil {
+		if isNilValue(expected) && isNilValue(actual) {
+			return nil
+		}
+		return []mismatch{mismatchNotEqual(path, expected, actual)}
+	}
+	var (
+		exp = reflect.ValueOf(expected)
+		act = reflect.ValueOf(actual)
+	)
+	if exp.Kind() != reflect.Pointer && act.Kind() == reflect.Pointer {
+		if act.IsNil() {
+			return []mismatch{mismatchNotEqual(path, expected, actual)}
+		}
+		return m.match(path, expected, act.Elem().Interface())
+	}
+	if m.isVisited(exp, act) {
+		return nil
+	}
+	switch {
+	case exp.Kind() == reflect.Pointer && act.Kind() == reflect.Pointer && exp.Type() == act.Type():
+		if exp.IsNil() || act.IsNil() {
+			if exp.IsNil() && act.IsNil() {
+				return nil
+			}
+			return []mismatch{mismatchNotEqual(path, expected, actual)}
+		}
+		return m.match(path, exp.Elem().Interface(), act.Elem().Interface())
+
+	case exp.Kind() == reflect.Struct && exp.Type() == act.Type():
+		return m.matchStruct(path, exp, act)
+
+	case exp.Kind() == reflect.Map && act.Kind() == reflect.Map:
+		return m.matchMap(path, exp, act)
+
+	case isSequence(exp) && isSequence(act):
+		return m.matchSequence(path, exp, act)
+
+	default:
+		if isEqualValue(exp, act) {
+			return nil
+		}
+		return []mismatch{mismatchNotEqual(path, expected, actual)}
+	}
+}
+
+// isVisited reports whether the pair of references was already matched,
+// which prevents endless recursion with cyclic values.
+func (m *matching) isVisited(exp, act reflect.Value) bool {
+	if exp.Kind() != act.Kind() || exp.Type() != act.Type() {
+		return false
+	}
+	switch exp.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Pointer:
+		if exp.IsNil() || act.IsNil() {
+			return false
+		}
+	default:
+		return false
+	}
+	v := matchingVisit{exp: exp.Pointer(), act: act.Pointer(), typ: exp.Type()}
+	if _, ok := m.visited[v]; ok {
+		return true
+	}
+	m.visited[v] = struct{}{}
+	return false
+}
+
+func mismatchNotEqual(path string, expected, actual any) mismatch {
+	return mismatch{
+		Path:  path,
+		Cause: fmt.Sprintf("expected %s, got %s", pp.Format(expected), pp.Format(actual)),
+	}
+}
+
+func isNilValue(v any) bool {
+	return v == nil || reflects.IsNil(v)
+}
+
+func isSequence(rv reflect.Value) bool {
+	return rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array
+}
+
+func isEqualValue(exp, act reflect.Value) bool {
+	if exp.Type() != act.Type() {
+		if converted, ok := convertLossless(exp, act.Type()); ok {
+			exp = converted
+		}
+	}
+	return reflect.DeepEqual(exp.Interface(), act.Interface())
+}
+
+// convertLossless converts a basic value to the type,
+// when both belong to the same kind family, and the conversion keeps the value.
+// Numbers are only converted to numbers, when converting them back yields the original value,
+// thus 1.9 is not converted to 1, and 65 is not converted to "A".
+func convertLossless(v reflect.Value, typ reflect.Type) (reflect.Value, bool) {
+	if !v.IsValid() || !isBasicKind(v.Kind()) || !isBasicKind(typ.Kind()) || !v.CanConvert(typ) {
+		return reflect.Value{}, false
+	}
+	if v.Type() == typ {
+		return v, true
+	}
+	switch {
+	case v.Kind() == reflect.String && typ.Kind() == reflect.String,
+		v.Kind() == reflect.Bool && typ.Kind() == reflect.Bool:
+		return v.Convert(typ), true
+	case isNumericKind(v.Kind()) && isNumericKind(typ.Kind()):
+		converted := v.Convert(typ)
+		if isSignChanged(v, converted) || converted.Convert(v.Type()).Interface() != v.Interface() {
+			return reflect.Value{}, false
+		}
+		return converted, true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	return isBasicKind(kind) && kind != reflect.Bool && kind != reflect.String
+}
+
+// isSignChanged reports whether a conversion between signed and unsigned integers flipped the sign of the value,
+// like uint8(255) to int8(-1), which would survive the round trip.
+func isSignChanged(from, to reflect.Value) bool {
+	return isNegative(from) != isNegative(to)
+}
+
+func isNegative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() < 0
+	default:
+		return false
+	}
+}
+
+func isBasicKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *matching) matchFields(path string, fields Fields, actual any) []mismatch {
+	act := reflect.ValueOf(actual)
+	for act.Kind() == reflect.Pointer && !act.IsNil() {
+		act = act.Elem()
+	}
+	if act.Kind() != reflect.Struct {
+		return []mismatch{{Path: path, Cause: fmt.Sprintf("expected a struct, got %s", pp.Format(actual))}}
+	}
+	act = reflects.Addressable(act)
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var mismatches []mismatch
+	for _, name := range names {
+		fieldPath := path + "." + name
+		field := act.FieldByName(name)
+		if !field.IsValid() {
+			mismatches = append(mismatches, mismatch{
+				Path:  fieldPath,
+				Cause: fmt.Sprintf("%s has no such field", act.Type().String()),
+			})
+			continue
+		}
+		mismatches = append(mismatches, m.match(fieldPath, fields[name], reflects.Accessible(field).Interface())...)
+	}
+	return mismatches
+}
+
+func (m *matching) matchStruct(path string, exp, act reflect.Value) []mismatch {
+	exp, act = reflects.Addressable(exp), reflects.Addressable(act)
+	var mismatches []mismatch
+	for i, l := 0, exp.NumField(); i < l; i++ {
+		mismatches = append(mismatches, m.match(
+			path+"."+exp.Type().Field(i).Name,
+			reflects.Accessible(exp.Field(i)).Interface(),
+			reflects.Accessible(act.Field(i)).Interface(),
+		)...)
+	}
+	return mismatches
+}
+
+func (m *matching) matchMap(path string, exp, act reflect.Value) []mismatch {
+	var mismatches []mismatch
+	expected := make(map[any]struct{})
+	for _, key := range sortedMapKeys(exp) {
+		actKey := key
+		if key.Type() != act.Type().Key() {
+			converted, ok := convertLossless(key, act.Type().Key())
+			if !ok {
+				mismatches = append(mismatches, mismatch{
+					Path:  fmt.Sprintf("%s[%s]", path, pp.Format(key.Interface())),
+					Cause: fmt.Sprintf("key type %s is not compatible with %s", key.Type().String(), act.Type().Key().String()),
+				})
+				continue
+			}
+			actKey = converted
+		}
+		expected[actKey.Interface()] = struct{}{}
+		keyPath := fmt.Sprintf("%s[%s]", path, pp.Format(key.Interface()))
+		value := act.MapIndex(actKey)
+		if !value.IsValid() {
+			mismatches = append(mismatches, mismatch{Path: keyPath, Cause: "key is missing"})
+			continue
+		}
+		mismatches = append(mismatches, m.match(keyPath, exp.MapIndex(key).Interface(), value.Interface())...)
+	}
+	for _, key := range sortedMapKeys(act) {
+		if _, ok := expected[key.Interface()]; ok {
+			continue
+		}
+		mismatches = append(mismatches, mismatch{
+			Path:  fmt.Sprintf("%s[%s]", path, pp.Format(key.Interface())),
+			Cause: "unexpected key",
+		})
+	}
+	return mismatches
+}
+
+func sortedMapKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return pp.Format(keys[i].Interface()) < pp.Format(keys[j].Interface())
+	})
+	return keys
+}
+
+func (m *matching) matchSequence(path string, exp, act reflect.Value) []mismatch {
+	if exp.Len() != act.Len() {
+		return []mismatch{{
+			Path:  path,
+			Cause: fmt.Sprintf("expected length %d, got %d: %s", exp.Len(), act.Len(), pp.Format(act.Interface())),
+		}}
+	}
+	var mismatches []mismatch
+	for i, l := 0, exp.Len(); i < l; i++ {
+		mismatches = append(mismatches, m.match(
+			fmt.Sprintf("%s[%d]", path, i),
+			exp.Index(i).Interface(),
+			act.Index(i).Interface(),
+		)...)
+	}
+	return mismatches
+}
+
+// Any is a Matcher that accepts any value of the type T.
+func Any[T any]() Matcher {
+	return MatcherFunc(func(actual any) error {
+		if _, ok := actual.(T); ok {
+			return nil
+		}
+		return fmt.Errorf("expected any %s, got %s", reflect.TypeOf((*T)(nil)).Elem().String(), pp.Format(actual))
+	})
+}
+
+// Regexp is a Matcher that accepts strings and byte slices which match the regular expression pattern.
+// When the pattern is not a valid regular expression, the Matcher reports the compile error as a mismatch.
+func Regexp(pattern string) Matcher {
+	rgx, err := regexp.Compile(pattern)
+	if err != nil {
+		return MatcherFunc(func(actual any) error {
+			return fmt.Errorf("invalid regular expression /%s/: %w", pattern, err)
+		})
+	}
+	return MatcherFunc(func(actual any) error {
+		var ok bool
+		switch v := actual.(type) {
+		case string:
+			ok = rgx.MatchString(v)
+		case []byte:
+			ok = rgx.Match(v)
+		default:
+			rv := reflect.ValueOf(actual)
+			if rv.Kind() == reflect.String {
+				ok = rgx.MatchString(rv.String())
+			}
+		}
+		if ok {
+			return nil
+		}
+		return fmt.Errorf("expected a match for /%s/, got %s", pattern, pp.Format(actual))
+	})
+}
+
+type ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Between is a Matcher that accepts values in the closed interval of min and max.
+// The actual value must be of the type T, or convertible to it without losing information.
+func Between[T ordered](min, max T) Matcher {
+	return MatcherFunc(func(actual any) error {
+		v, ok := actual.(T)
+		if !ok {
+			typ := reflect.TypeOf((*T)(nil)).Elem()
+			rv, ok := convertLossless(reflect.ValueOf(actual), typ)
+			if !ok {
+				return fmt.Errorf("expected a %s between %s and %s, got %s", typ.String(), pp.Format(min), pp.Format(max), pp.Format(actual))
+			}
+			v = rv.Interface().(T)
+		}
+		if min <= v && v <= max {
+			return nil
+		}
+		return fmt.Errorf("expected a value between %s and %s, got %s", pp.Format(min), pp.Format(max), pp.Format(actual))
+	})
+}
+
+// Len is a Matcher that accepts strings, slices, arrays, maps and channels with the length of n.
+func Len(n int) Matcher {
+	return MatcherFunc(func(actual any) error {
+		rv := reflect.ValueOf(actual)
+		switch rv.Kind() {
+		case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+			if rv.Len() == n {
+				return nil
+			}
+			return fmt.Errorf("expected length %d, got %d: %s", n, rv.Len(), pp.Format(actual))
+		default:
+			return fmt.Errorf("expected a value with length %d, got %s", n, pp.Format(actual))
+		}
+	})
+}
+
+// ContainsMatching is a Matcher that accepts slices and arrays, which have for each expectation at least one matching element.
+// The expectations can be values or Matchers, just like the expected value of Asserter.Match.
+func ContainsMatching(expectations ...any) Matcher {
+	return MatcherFunc(func(actual any) error {
+		rv := reflect.ValueOf(actual)
+		if !isSequence(rv) {
+			return fmt.Errorf("expected a slice or an array, got %s", pp.Format(actual))
+		}
+	search:
+		for i, exp := range expectations {
+			for j, l := 0, rv.Len(); j < l; j++ {
+				if isMatching(exp, rv.Index(j).Interface()) {
+					continue search
+				}
+			}
+			return fmt.Errorf("no element matches the expectation #%d: %s", i+1, pp.Format(exp))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,250 @@
+package assert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/doubles"
+	"github.com/adamluzsi/testcase/sandbox"
+)
+
+type MatchExampleUser struct {
+	ID        string
+	Name      string
+	Age       int
+	Tags      []string
+	CreatedAt time.Time
+	Address   *MatchExampleAddress
+	Meta      map[string]any
+}
+
+type MatchExampleAddress struct {
+	City string
+	Zip  string
+}
+
+func TestAsserter_Match(t *testing.T) {
+	now := time.Now()
+	user := MatchExampleUser{
+		ID:        "0f7a3e2c",
+		Name:      "John",
+		Age:       42,
+		Tags:      []string{"admin", "user"},
+		CreatedAt: now,
+		Address:   &MatchExampleAddress{City: "Budapest", Zip: "1111"},
+		Meta:      map[string]any{"score": 7, "source": "signup-form"},
+	}
+
+	type TestCase struct {
+		Expected      any
+		Actual        any
+		OK            bool
+		FailurePaths  []string
+		FailureCauses []string
+	}
+	for name, tc := range map[string]TestCase{
+		"when values are equal": {
+			Expected: user,
+			Actual:   user,
+			OK:       true,
+		},
+		"when fields partially match": {
+			Expected: assert.Fields{
+				"ID":        assert.Any[string](),
+				"Name":      "John",
+				"Age":       assert.Between(18, 99),
+				"Tags":      assert.ContainsMatching("admin"),
+				"CreatedAt": assert.Any[time.Time](),
+				"Address":   assert.Fields{"City": assert.Regexp(`^Buda`)},
+				"Meta": map[string]any{
+					"score":  assert.Between(0, 10),
+					"source": assert.Regexp(`form$`),
+				},
+			},
+			Actual: user,
+			OK:     true,
+		},
+		"when pointer is matched with Fields": {
+			Expected: assert.Fields{"City": "Budapest"},
+			Actual:   user.Address,
+			OK:       true,
+		},
+		"when a nested field doesn't match, then its path is reported": {
+			Expected: assert.Fields{
+				"Name":    "John",
+				"Address": assert.Fields{"Zip": assert.Regexp(`^9`)},
+			},
+			Actual:        user,
+			FailurePaths:  []string{"actual.Address.Zip"},
+			FailureCauses: []string{`/^9/`},
+		},
+		"when multiple values don't match, then all of them are reported": {
+			Expected: assert.Fields{
+				"Name": "Jane",
+				"Tags": []string{"admin", "guest"},
+				"Meta": map[string]any{"score": 7},
+			},
+			Actual:       user,
+			FailurePaths: []string{"actual.Name", "actual.Tags[1]", `actual.Meta["source"]`},
+		},
+		"when field is missing from the struct": {
+			Expected:      assert.Fields{"Email": assert.Any[string]()},
+			Actual:        user,
+			FailurePaths:  []string{"actual.Email"},
+			FailureCauses: []string{"has no such field"},
+		},
+		"when map key is missing": {
+			Expected:      map[string]int{"foo": 1, "bar": 2},
+			Actual:        map[string]int{"foo": 1},
+			FailurePaths:  []string{`actual["bar"]`},
+			FailureCauses: []string{"key is missing"},
+		},
+		"when slice has different length": {
+			Expected:      []any{assert.Any[int](), 2},
+			Actual:        []int{1, 2, 3},
+			FailurePaths:  []string{"actual"},
+			FailureCauses: []string{"expected length 2, got 3"},
+		},
+		"when slice elements match": {
+			Expected: []any{assert.Any[int](), 2, assert.Between(3, 5)},
+			Actual:   []int{1, 2, 3},
+			OK:       true,
+		},
+		"when basic types differ but convertible": {
+			Expected: map[string]any{"n": 42},
+			Actual:   map[string]int64{"n": 42},
+			OK:       true,
+		},
+		"when Len matches": {
+			Expected: assert.Fields{"Tags": assert.Len(2)},
+			Actual:   user,
+			OK:       true,
+		},
+		"when Len doesn't match": {
+			Expected:      assert.Fields{"Tags": assert.Len(3)},
+			Actual:        user,
+			FailurePaths:  []string{"actual.Tags"},
+			FailureCauses: []string{"expected length 3, got 2"},
+		},
+		"when ContainsMatching finds no matching element": {
+			Expected:      assert.ContainsMatching(assert.Fields{"City": "Vienna"}),
+			Actual:        []MatchExampleAddress{{City: "Budapest"}, {City: "Prague"}},
+			FailurePaths:  []string{"actual"},
+			FailureCauses: []string{"no element matches the expectation #1"},
+		},
+		"when Any receives a different type": {
+			Expected:      assert.Any[string](),
+			Actual:        42,
+			FailurePaths:  []string{"actual"},
+			FailureCauses: []string{"expected any string"},
+		},
+		"when Between receives a value out of range": {
+			Expected:      assert.Between(1.0, 2.0),
+			Actual:        2.5,
+			FailurePaths:  []string{"actual"},
+			FailureCauses: []string{"expected a value between"},
+		},
+		"when an int is expected as a string, the conversion is not applied": {
+			Expected:      65,
+			Actual:        "A",
+			FailurePaths:  []string{"actual"},
+			FailureCauses: []string{"expected 65"},
+		},
+		"when a float is expected as an int, the fraction is not truncated": {
+			Expected:      1.9,
+			Actual:        1,
+			FailurePaths:  []string{"actual"},
+			FailureCauses: []string{"expected 1.9"},
+		},
+		"when a negative int is expected as an unsigned int, the value is not wrapped around": {
+			Expected:      int64(-1),
+			Actual:        uint8(255),
+			FailurePaths:  []string{"actual"},
+			FailureCauses: []string{"expected -1"},
+		},
+		"when an int constant is expected as a float with the same value": {
+			Expected: 1,
+			Actual:   1.0,
+			OK:       true,
+		},
+		"when Between receives a value which is out of range only without truncation": {
+			Expected:      assert.Between(0, 10),
+			Actual:        10.9,
+			FailurePaths:  []string{"actual"},
+			FailureCauses: []string{"expected a int between"},
+		},
+		"when Regexp has an invalid pattern, it is reported as a mismatch": {
+			Expected:      assert.Fields{"Name": assert.Regexp(`(`)},
+			Actual:        MatchExampleUser{Name: "John"},
+			FailurePaths:  []string{"actual.Name"},
+			FailureCauses: []string{"invalid regular expression /(/", "missing closing )"},
+		},
+		"when nil is expected": {
+			Expected: assert.Fields{"Address": nil},
+			Actual:   MatchExampleUser{},
+			OK:       true,
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			dtb := &doubles.TB{}
+			out := sandbox.Run(func() {
+				assert.Must(dtb).Match(tc.Expected, tc.Actual, "custom message")
+			})
+			assert.Equal(t, tc.OK, out.OK)
+			assert.Equal(t, tc.OK, !dtb.IsFailed)
+			if tc.OK {
+				return
+			}
+			logs := dtb.Logs.String()
+			assert.Contain(t, logs, "custom message")
+			for _, path := range tc.FailurePaths {
+				assert.Contain(t, logs, path+":")
+			}
+			for _, cause := range tc.FailureCauses {
+				assert.Contain(t, logs, cause)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	assert.Match(t, assert.Fields{"City": assert.Any[string]()}, MatchExampleAddress{City: "Budapest"})
+
+	dtb := &doubles.TB{}
+	out := sandbox.Run(func() { assert.Match(dtb, assert.Fields{"City": "Vienna"}, MatchExampleAddress{City: "Budapest"}) })
+	assert.False(t, out.OK)
+	assert.True(t, dtb.IsFailed)
+}
+
+type MatchExampleNode struct {
+	Value int
+	Next  *MatchExampleNode
+}
+
+func TestAsserter_Match_cyclicValues(t *testing.T) {
+	makeCycle := func(value int) *MatchExampleNode {
+		n := &MatchExampleNode{Value: value}
+		n.Next = n
+		return n
+	}
+	t.Run("when the cyclic values are equal, then they match", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		assert.Should(dtb).Match(makeCycle(42), makeCycle(42))
+		assert.False(t, dtb.IsFailed)
+	})
+	t.Run("when the cyclic values differ, then the mismatch is reported", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		assert.Should(dtb).Match(makeCycle(42), makeCycle(24))
+		assert.True(t, dtb.IsFailed)
+		assert.Contain(t, dtb.Logs.String(), "actual.Value: expected 42, got 24")
+	})
+	t.Run("when a cyclic slice is matched against itself, then they match", func(t *testing.T) {
+		list := []any{1, nil}
+		list[1] = list
+		dtb := &doubles.TB{}
+		assert.Should(dtb).Match(list, list)
+		assert.False(t, dtb.IsFailed)
+	})
+}
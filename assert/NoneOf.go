@@ -0,0 +1,47 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase/internal/fmterror"
+)
+
+// NoneOf is an assertion helper that allows you run NoneOf.Test assertion blocks, where none of them should succeed.
+// When any of the blocks pass, Finish reports which of them did.
+// common usage use-cases:
+//   - list of structures, where none of them should match a given expectation.
+//   - fan out scenario, where you need to check that none of the workers received the event.
+type NoneOf struct {
+	TB   testing.TB
+	Fail func()
+
+	results blockResults
+}
+
+// Test will test a block of assertion that must fail in order to make NoneOf pass.
+// You can have as much NoneOf.Test calls as you need, and all of them are executed.
+// Using Test is safe for concurrently.
+func (no *NoneOf) Test(blk func(it It)) {
+	no.TB.Helper()
+	no.results.Run(no.TB, blk)
+}
+
+// Finish will check if none of the assertions succeeded, and reports the ones which did.
+func (no *NoneOf) Finish(msg ...interface{}) {
+	no.TB.Helper()
+	passed := no.results.Filter(true)
+	if len(passed) == 0 {
+		return
+	}
+	summary := &strings.Builder{}
+	summary.WriteString(fmterror.Message{
+		Method:  "NoneOf",
+		Cause:   fmt.Sprintf("%d of the %d .Test succeeded, while none of them expected to", len(passed), no.results.Total()),
+		Message: msg,
+	}.String())
+	formatBlockResults(summary, passed)
+	no.TB.Log(summary.String())
+	no.Fail()
+}
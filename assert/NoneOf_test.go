@@ -0,0 +1,47 @@
+package assert_test
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/doubles"
+)
+
+func TestNoneOf(t *testing.T) {
+	t.Run("when every .Test fails, NoneOf yields no failure on .Finish", func(t *testing.T) {
+		stub := &doubles.TB{}
+		noneOf := &assert.NoneOf{TB: stub, Fail: stub.Fail}
+		noneOf.Test(func(it assert.It) { it.Must.True(false) })
+		noneOf.Test(func(it assert.It) { it.Should.True(false) })
+		noneOf.Finish()
+		assert.False(t, stub.IsFailed)
+		assert.Empty(t, stub.Logs.String())
+	})
+	t.Run("when a .Test passes, NoneOf yields failure on .Finish, and reports which one passed", func(t *testing.T) {
+		stub := &doubles.TB{}
+		noneOf := &assert.NoneOf{TB: stub, Fail: stub.Fail}
+		noneOf.Test(func(it assert.It) { it.Must.True(false) })
+		noneOf.Test(func(it assert.It) { it.Log("passing block") })
+		noneOf.Finish("msg")
+		assert.True(t, stub.IsFailed)
+		logs := stub.Logs.String()
+		assert.Contain(t, logs, "[NoneOf] 1 of the 2 .Test succeeded, while none of them expected to")
+		assert.Contain(t, logs, "msg")
+		assert.Contain(t, logs, "#2 NoneOf_test.go:")
+		assert.Contain(t, logs, "passing block")
+		assert.NotContain(t, logs, "#1 ")
+	})
+}
+
+func TestNoneOf_Test_race(t *testing.T) {
+	stub := &doubles.TB{}
+	noneOf := &assert.NoneOf{TB: stub, Fail: stub.Fail}
+	testcase.Race(func() {
+		noneOf.Test(func(it assert.It) {})
+	}, func() {
+		noneOf.Test(func(it assert.It) { it.Must.True(false) })
+	}, func() {
+		noneOf.Finish()
+	})
+}
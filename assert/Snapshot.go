@@ -0,0 +1,276 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/adamluzsi/testcase/internal/fmterror"
+	"github.com/adamluzsi/testcase/pp"
+)
+
+// EnvKeyUpdateSnapshots is the environment variable key that will be checked
+// to determine whether the snapshot files should be updated with the actual values,
+// instead of asserting against them.
+// It also removes the orphaned snapshot files.
+const EnvKeyUpdateSnapshots = `TESTCASE_UPDATE_SNAPSHOTS`
+
+// SnapshotDir is the directory, relative to the package of the test, where the snapshot files are stored.
+const SnapshotDir = `testdata/__snapshots__`
+
+// MatchSnapshot asserts that the value matches its snapshot (golden file),
+// which is stored under testdata/__snapshots__/<test name>/<name>.snap.
+//
+// Strings and byte slices are stored as they are, json.RawMessage is stored as an indented JSON,
+// and every other value is serialised with pp.Format.
+// When the snapshot file doesn't exist yet, the assertion fails.
+// To create the missing snapshots and update the existing ones with the actual values,
+// set TESTCASE_UPDATE_SNAPSHOTS to true.
+//
+// At the end of a test, the snapshot files in the test's directory which were not referenced are reported as orphaned.
+// To report the snapshot files of deleted or renamed tests as well, use SnapshotTestMain.
+func (a Asserter) MatchSnapshot(name string, value any, msg ...any) {
+	const FnMethod = "MatchSnapshot"
+	a.TB.Helper()
+	path := snapshotPath(a.TB.Name(), name)
+	actual, err := snapshotSerialise(value)
+	if err != nil {
+		a.fn(fmterror.Message{
+			Method:  FnMethod,
+			Cause:   "Unable to serialise the value for the snapshot.",
+			Message: msg,
+			Values: []fmterror.Value{
+				{Label: "error", Value: err.Error()},
+			},
+		})
+		return
+	}
+	snapshots.Reference(a.TB, path)
+	expected, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !isSnapshotUpdate() {
+		a.fn(fmterror.Message{
+			Method:  FnMethod,
+			Cause:   "Snapshot is missing.",
+			Message: msg,
+			Values: []fmterror.Value{
+				{Label: "snapshot", Value: path},
+				{Label: "create", Value: EnvKeyUpdateSnapshots + "=true"},
+			},
+		})
+		return
+	}
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && isSnapshotUpdate()) {
+		if err := writeSnapshot(path, actual); err != nil {
+			a.TB.Fatalf("[%s] %s", FnMethod, err.Error())
+			return
+		}
+		a.TB.Logf("[%s] snapshot is written: %s", FnMethod, path)
+		return
+	}
+	if err != nil {
+		a.TB.Fatalf("[%s] %s", FnMethod, err.Error())
+		return
+	}
+	if bytes.Equal(expected, actual) {
+		return
+	}
+	a.fnWithDetails(fmterror.Message{
+		Method:  FnMethod,
+		Cause:   "Value doesn't match the snapshot.",
+		Message: msg,
+		Values: []fmterror.Value{
+			{Label: "snapshot", Value: path},
+			{Label: "update", Value: EnvKeyUpdateSnapshots + "=true"},
+		},
+	}, pp.DiffString(string(expected), string(actual)))
+}
+
+func snapshotSerialise(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case json.RawMessage:
+		buf := &bytes.Buffer{}
+		if err := json.Indent(buf, v, "", "\t"); err != nil {
+			return nil, err
+		}
+		buf.WriteString("\n")
+		return buf.Bytes(), nil
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return []byte(pp.Format(v) + "\n"), nil
+	}
+}
+
+func writeSnapshot(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+func isSnapshotUpdate() bool {
+	ok, _ := strconv.ParseBool(os.Getenv(EnvKeyUpdateSnapshots))
+	return ok
+}
+
+var rgxSnapshotUnsafeChars = regexp.MustCompile(`[^A-Za-z0-9._#=+-]`)
+
+func snapshotPath(testName, name string) string {
+	return filepath.Join(snapshotTestDir(testName), snapshotFileName(name)+".snap")
+}
+
+func snapshotTestDir(testName string) string {
+	parts := []string{filepath.FromSlash(SnapshotDir)}
+	for _, part := range strings.Split(testName, "/") {
+		parts = append(parts, snapshotFileName(part))
+	}
+	return filepath.Join(parts...)
+}
+
+func snapshotFileName(name string) string {
+	name = rgxSnapshotUnsafeChars.ReplaceAllString(name, "_")
+	if name == "" || strings.Trim(name, ".") == "" {
+		name = strings.Repeat("_", len(name)+1)
+	}
+	return name
+}
+
+var snapshots = &snapshotRegistry{}
+
+// snapshotRegistry keeps track of the snapshot files referenced by the tests,
+// to report the orphaned snapshot files at the end of the tests.
+type snapshotRegistry struct {
+	mutex      sync.Mutex
+	references map[string]struct{}
+	tests      map[string]struct{}
+}
+
+func (r *snapshotRegistry) Reference(tb testing.TB, path string) {
+	tb.Helper()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.references == nil {
+		r.references = make(map[string]struct{})
+		r.tests = make(map[string]struct{})
+	}
+	r.references[path] = struct{}{}
+	if _, ok := r.tests[tb.Name()]; ok {
+		return
+	}
+	r.tests[tb.Name()] = struct{}{}
+	dir := snapshotTestDir(tb.Name())
+	tb.Cleanup(func() {
+		tb.Helper()
+		r.reportOrphans(tb, dir)
+	})
+}
+
+func (r *snapshotRegistry) reportOrphans(tb testing.TB, dir string) {
+	tb.Helper()
+	if tb.Failed() || tb.Skipped() || isPartialTestRun() {
+		return
+	}
+	orphans := r.orphans(dir)
+	if len(orphans) == 0 {
+		return
+	}
+	if isSnapshotUpdate() {
+		for _, path := range orphans {
+			_ = os.Remove(path)
+		}
+		tb.Logf("[MatchSnapshot] orphaned snapshot files are removed:\n\t%s", strings.Join(orphans, "\n\t"))
+		return
+	}
+	tb.Logf("[MatchSnapshot] the following snapshot files are not referenced by any test:\n\t%s\nto remove them, set %s=true",
+		strings.Join(orphans, "\n\t"), EnvKeyUpdateSnapshots)
+}
+
+func (r *snapshotRegistry) orphans(dir string, skipDirs ...string) []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var orphans []string
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			for _, skip := range skipDirs {
+				if path == skip {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".snap" {
+			return nil
+		}
+		if _, ok := r.references[path]; !ok {
+			orphans = append(orphans, path)
+		}
+		return nil
+	})
+	sort.Strings(orphans)
+	return orphans
+}
+
+// testDirs returns the snapshot directories of the tests, which already reported their orphaned snapshot files.
+func (r *snapshotRegistry) testDirs() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var dirs []string
+	for name := range r.tests {
+		dirs = append(dirs, snapshotTestDir(name))
+	}
+	return dirs
+}
+
+// SnapshotTestMain runs the tests of the package, and then reports the snapshot files under SnapshotDir
+// which were not referenced by any test during the run, like the snapshots of deleted or renamed tests.
+// When TESTCASE_UPDATE_SNAPSHOTS is set to true, these orphaned snapshot files are removed instead.
+// It returns the exit code of the test run.
+//
+//	func TestMain(m *testing.M) { os.Exit(assert.SnapshotTestMain(m)) }
+func SnapshotTestMain(m interface{ Run() int }) int {
+	code := m.Run()
+	if code != 0 || isPartialTestRun() {
+		return code
+	}
+	orphans := snapshots.orphans(filepath.FromSlash(SnapshotDir), snapshots.testDirs()...)
+	if len(orphans) == 0 {
+		return code
+	}
+	if isSnapshotUpdate() {
+		for _, path := range orphans {
+			_ = os.Remove(path)
+		}
+		fmt.Fprintf(os.Stderr, "[MatchSnapshot] orphaned snapshot files are removed:\n\t%s\n", strings.Join(orphans, "\n\t"))
+		return code
+	}
+	fmt.Fprintf(os.Stderr, "[MatchSnapshot] the following snapshot files are not referenced by any test:\n\t%s\nto remove them, set %s=true\n",
+		strings.Join(orphans, "\n\t"), EnvKeyUpdateSnapshots)
+	return code
+}
+
+// isPartialTestRun reports whether the tests are filtered,
+// in which case the snapshots of the filtered out tests would look orphaned.
+func isPartialTestRun() bool {
+	for _, name := range []string{"test.run", "test.skip"} {
+		if f := flag.Lookup(name); f != nil && f.Value.String() != "" {
+			return true
+		}
+	}
+	return false
+}
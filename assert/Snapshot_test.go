@@ -0,0 +1,237 @@
+package assert_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/doubles"
+	"github.com/adamluzsi/testcase/sandbox"
+)
+
+func chdirTemp(tb testing.TB) string {
+	tb.Helper()
+	wd, err := os.Getwd()
+	assert.NoError(tb, err)
+	dir := tb.TempDir()
+	assert.NoError(tb, os.Chdir(dir))
+	tb.Cleanup(func() { _ = os.Chdir(wd) })
+	return dir
+}
+
+// withoutRunFilter makes the test run look like a complete run,
+// so the orphaned snapshots are reported even when the tests are filtered with -run.
+func withoutRunFilter(tb testing.TB) {
+	f := flag.Lookup("test.run")
+	if f == nil {
+		return
+	}
+	og := f.Value.String()
+	assert.NoError(tb, flag.Set("test.run", ""))
+	tb.Cleanup(func() { _ = flag.Set("test.run", og) })
+}
+
+// createSnapshot writes the snapshot file of the value, as the given test would with TESTCASE_UPDATE_SNAPSHOTS.
+func createSnapshot(tb testing.TB, testName, name string, value any) {
+	tb.Helper()
+	og, ok := os.LookupEnv(assert.EnvKeyUpdateSnapshots)
+	assert.NoError(tb, os.Setenv(assert.EnvKeyUpdateSnapshots, "true"))
+	defer func() {
+		if ok {
+			_ = os.Setenv(assert.EnvKeyUpdateSnapshots, og)
+		} else {
+			_ = os.Unsetenv(assert.EnvKeyUpdateSnapshots)
+		}
+	}()
+	stub := &doubles.TB{StubName: testName}
+	assert.Snapshot(stub, name, value)
+	assert.False(tb, stub.IsFailed)
+}
+
+func TestSnapshot(t *testing.T) {
+	type Example struct {
+		Foo string
+		Bar int
+	}
+
+	t.Run("when snapshot is missing, then assertion fails", func(t *testing.T) {
+		t.Setenv(assert.EnvKeyUpdateSnapshots, "false")
+		chdirTemp(t)
+		stub := &doubles.TB{StubName: "TestExample"}
+		out := sandbox.Run(func() {
+			assert.Snapshot(stub, "example", Example{Foo: "foo", Bar: 42})
+		})
+		assert.False(t, out.OK)
+		assert.True(t, stub.IsFailed)
+		assert.Contain(t, stub.Logs.String(), "Snapshot is missing.")
+		assert.Contain(t, stub.Logs.String(), assert.EnvKeyUpdateSnapshots+"=true")
+		_, err := os.Stat(filepath.Join("testdata", "__snapshots__", "TestExample", "example.snap"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("when snapshot is missing and updating is requested, it is created", func(t *testing.T) {
+		t.Setenv(assert.EnvKeyUpdateSnapshots, "true")
+		chdirTemp(t)
+		stub := &doubles.TB{StubName: "TestExample/sub test"}
+		assert.Snapshot(stub, "example", Example{Foo: "foo", Bar: 42})
+		assert.False(t, stub.IsFailed)
+
+		content, err := os.ReadFile(filepath.Join("testdata", "__snapshots__", "TestExample", "sub_test", "example.snap"))
+		assert.NoError(t, err)
+		assert.Contain(t, string(content), `Foo: "foo"`)
+	})
+
+	t.Run("when snapshot matches the value, then assertion passes", func(t *testing.T) {
+		t.Setenv(assert.EnvKeyUpdateSnapshots, "false")
+		chdirTemp(t)
+		createSnapshot(t, "TestExample", "example", Example{Foo: "foo", Bar: 42})
+		stub := &doubles.TB{StubName: "TestExample"}
+		assert.Snapshot(stub, "example", Example{Foo: "foo", Bar: 42})
+		assert.False(t, stub.IsFailed)
+	})
+
+	t.Run("when snapshot doesn't match the value, then assertion fails with a diff", func(t *testing.T) {
+		t.Setenv(assert.EnvKeyUpdateSnapshots, "false")
+		chdirTemp(t)
+		createSnapshot(t, "TestExample", "example", Example{Foo: "foo", Bar: 42})
+		stub := &doubles.TB{StubName: "TestExample"}
+		out := sandbox.Run(func() {
+			assert.Snapshot(stub, "example", Example{Foo: "foo", Bar: 24})
+		})
+		assert.False(t, out.OK)
+		assert.True(t, stub.IsFailed)
+		assert.Contain(t, stub.Logs.String(), "Value doesn't match the snapshot.")
+		assert.Contain(t, stub.Logs.String(), assert.EnvKeyUpdateSnapshots)
+		assert.Contain(t, stub.Logs.String(), "Bar: 42,")
+		assert.Contain(t, stub.Logs.String(), "Bar: 24,")
+	})
+
+	t.Run("when updating is requested, then snapshot is overwritten", func(t *testing.T) {
+		chdirTemp(t)
+		createSnapshot(t, "TestExample", "example", "foo")
+		t.Setenv(assert.EnvKeyUpdateSnapshots, "true")
+		stub := &doubles.TB{StubName: "TestExample"}
+		assert.Snapshot(stub, "example", "bar")
+		assert.False(t, stub.IsFailed)
+		content, err := os.ReadFile(filepath.Join("testdata", "__snapshots__", "TestExample", "example.snap"))
+		assert.NoError(t, err)
+		assert.Equal(t, "bar", string(content))
+	})
+
+	t.Run("raw bytes and JSON are stored in their own format", func(t *testing.T) {
+		t.Setenv(assert.EnvKeyUpdateSnapshots, "true")
+		chdirTemp(t)
+		stub := &doubles.TB{StubName: "TestExample"}
+		assert.Snapshot(stub, "bytes", []byte("hello"))
+		assert.Snapshot(stub, "json", json.RawMessage(`{"foo":"bar"}`))
+		content, err := os.ReadFile(filepath.Join("testdata", "__snapshots__", "TestExample", "bytes.snap"))
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(content))
+		content, err = os.ReadFile(filepath.Join("testdata", "__snapshots__", "TestExample", "json.snap"))
+		assert.NoError(t, err)
+		assert.Equal(t, "{\n\t\"foo\": \"bar\"\n}\n", string(content))
+	})
+
+	t.Run("orphaned snapshot files are reported at the end of the test", func(t *testing.T) {
+		t.Setenv(assert.EnvKeyUpdateSnapshots, "false")
+		withoutRunFilter(t)
+		chdirTemp(t)
+		orphan := filepath.Join("testdata", "__snapshots__", "TestOrphan", "old.snap")
+		assert.NoError(t, os.MkdirAll(filepath.Dir(orphan), 0755))
+		assert.NoError(t, os.WriteFile(orphan, []byte("old"), 0644))
+
+		assert.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(orphan), "new.snap"), []byte("new"), 0644))
+		stub := &doubles.TB{StubName: "TestOrphan"}
+		assert.Snapshot(stub, "new", "new")
+		stub.Finish()
+		assert.False(t, stub.IsFailed)
+		assert.Contain(t, stub.Logs.String(), "not referenced by any test")
+		assert.Contain(t, stub.Logs.String(), orphan)
+		assert.NotContain(t, stub.Logs.String(), "\t"+filepath.Join(filepath.Dir(orphan), "new.snap"))
+	})
+
+	t.Run("orphaned snapshot files are removed during update", func(t *testing.T) {
+		t.Setenv(assert.EnvKeyUpdateSnapshots, "true")
+		withoutRunFilter(t)
+		chdirTemp(t)
+		orphan := filepath.Join("testdata", "__snapshots__", "TestOrphanUpdate", "old.snap")
+		assert.NoError(t, os.MkdirAll(filepath.Dir(orphan), 0755))
+		assert.NoError(t, os.WriteFile(orphan, []byte("old"), 0644))
+
+		stub := &doubles.TB{StubName: "TestOrphanUpdate"}
+		assert.Snapshot(stub, "new", "new")
+		stub.Finish()
+		_, err := os.Stat(orphan)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+type stubTestingM struct {
+	code int
+	run  func()
+}
+
+func (m stubTestingM) Run() int {
+	if m.run != nil {
+		m.run()
+	}
+	return m.code
+}
+
+func TestSnapshotTestMain(t *testing.T) {
+	orphanOfDeletedTest := func(tb testing.TB) string {
+		tb.Helper()
+		path := filepath.Join("testdata", "__snapshots__", "TestDeleted", "old.snap")
+		assert.NoError(tb, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(tb, os.WriteFile(path, []byte("old"), 0644))
+		return path
+	}
+
+	t.Run("the exit code of the test run is returned", func(t *testing.T) {
+		chdirTemp(t)
+		assert.Equal(t, 0, assert.SnapshotTestMain(stubTestingM{code: 0}))
+		assert.Equal(t, 1, assert.SnapshotTestMain(stubTestingM{code: 1}))
+	})
+
+	t.Run("orphaned snapshot files of tests which didn't run are removed during update", func(t *testing.T) {
+		t.Setenv(assert.EnvKeyUpdateSnapshots, "true")
+		withoutRunFilter(t)
+		chdirTemp(t)
+		orphan := orphanOfDeletedTest(t)
+		var referenced string
+		code := assert.SnapshotTestMain(stubTestingM{run: func() {
+			stub := &doubles.TB{StubName: "TestExisting"}
+			assert.Snapshot(stub, "new", "new")
+			stub.Finish()
+			referenced = filepath.Join("testdata", "__snapshots__", "TestExisting", "new.snap")
+		}})
+		assert.Equal(t, 0, code)
+		_, err := os.Stat(orphan)
+		assert.True(t, os.IsNotExist(err))
+		_, err = os.Stat(referenced)
+		assert.NoError(t, err)
+	})
+
+	t.Run("orphaned snapshot files are kept when updating is not requested", func(t *testing.T) {
+		t.Setenv(assert.EnvKeyUpdateSnapshots, "false")
+		withoutRunFilter(t)
+		chdirTemp(t)
+		orphan := orphanOfDeletedTest(t)
+		assert.Equal(t, 0, assert.SnapshotTestMain(stubTestingM{}))
+		_, err := os.Stat(orphan)
+		assert.NoError(t, err)
+	})
+
+	t.Run("when the test run failed, orphaned snapshot files are kept", func(t *testing.T) {
+		t.Setenv(assert.EnvKeyUpdateSnapshots, "true")
+		withoutRunFilter(t)
+		chdirTemp(t)
+		orphan := orphanOfDeletedTest(t)
+		assert.Equal(t, 1, assert.SnapshotTestMain(stubTestingM{code: 1}))
+		_, err := os.Stat(orphan)
+		assert.NoError(t, err)
+	})
+}
@@ -1,6 +1,7 @@
 package assert
 
 import (
+	"context"
 	"runtime"
 	"time"
 )
@@ -17,8 +18,12 @@ type Waiter struct {
 // Wait will attempt to wait a bit and leave breathing space for other goroutines to steal processing time.
 // It will also attempt to schedule other goroutines.
 func (w Waiter) Wait() {
+	w.waitCtx(context.Background())
+}
+
+func (w Waiter) waitCtx(ctx context.Context) {
 	finishTime := time.Now().Add(w.WaitDuration)
-	for time.Now().Before(finishTime) {
+	for ctx.Err() == nil && time.Now().Before(finishTime) {
 		runtime.Gosched()
 		time.Sleep(time.Nanosecond)
 	}
@@ -33,3 +38,12 @@ func (w Waiter) While(condition func() bool) {
 		w.Wait()
 	}
 }
+
+// WhileCtx will wait until a condition met, until the wait timeout, or until the context is cancelled.
+// The cancellation of the context also interrupts the waiting between two attempts.
+func (w Waiter) WhileCtx(ctx context.Context, condition func() bool) {
+	finishTime := time.Now().Add(w.Timeout)
+	for ctx.Err() == nil && condition() && time.Now().Before(finishTime) {
+		w.waitCtx(ctx)
+	}
+}
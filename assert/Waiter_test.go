@@ -1,6 +1,7 @@
 package assert_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -185,3 +186,36 @@ func TestWaiter(t *testing.T) {
 func BenchmarkWaiter(b *testing.B) {
 	SpecWaiter(b)
 }
+
+var _ assert.RetryStrategyCtx = assert.Waiter{}
+
+func TestWaiter_WhileCtx(t *testing.T) {
+	t.Run("when the condition is met, it stops", func(t *testing.T) {
+		var count int
+		assert.Waiter{Timeout: time.Minute}.WhileCtx(context.Background(), func() bool {
+			count++
+			return count < 3
+		})
+		assert.Equal(t, 3, count)
+	})
+	t.Run("when the context is cancelled, it stops before the timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 25*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		var count int
+		assert.Waiter{WaitDuration: time.Hour, Timeout: time.Hour}.WhileCtx(ctx, func() bool {
+			count++
+			return true
+		})
+		assert.Equal(t, 1, count)
+		assert.True(t, time.Since(start) < time.Minute)
+	})
+	t.Run("when the context is already cancelled, the condition is not attempted", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.Waiter{Timeout: time.Hour}.WhileCtx(ctx, func() bool {
+			t.Fatal("unexpected attempt")
+			return true
+		})
+	})
+}
@@ -0,0 +1,71 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adamluzsi/testcase/internal/fmterror"
+)
+
+const attemptSummaryMaxLength = 120
+
+// attemptHistory is a bounded history of the failed Eventually attempts.
+type attemptHistory struct {
+	// Size is the maximum number of the kept attempts.
+	Size int
+
+	records []attemptRecord
+	total   int
+}
+
+type attemptRecord struct {
+	Attempt   int
+	StartedAt time.Time
+	Duration  time.Duration
+	Messages  []string
+}
+
+func (h *attemptHistory) Add(rec attemptRecord) {
+	h.total++
+	if h.Size <= 0 {
+		return
+	}
+	if h.Size <= len(h.records) {
+		h.records = append(h.records[:0], h.records[len(h.records)-h.Size+1:]...)
+	}
+	h.records = append(h.records, rec)
+}
+
+// String formats the history as a compact timeline, with one line per attempt.
+// The timestamps are relative to the first kept attempt.
+func (h *attemptHistory) String() string {
+	out := &strings.Builder{}
+	out.WriteString(fmterror.Message{
+		Method: "Eventually",
+		Cause:  fmt.Sprintf("attempt history (last %d of %d failed attempts)", len(h.records), h.total),
+	}.String())
+	for _, rec := range h.records {
+		fmt.Fprintf(out, "\n#%d\t+%s\ttook %s", rec.Attempt, rec.StartedAt.Sub(h.records[0].StartedAt), rec.Duration)
+		if summary := h.summary(rec.Messages); summary != "" {
+			fmt.Fprintf(out, "\t%s", summary)
+		}
+	}
+	return out.String()
+}
+
+// summary shortens the messages of an attempt into a single line.
+func (h *attemptHistory) summary(msgs []string) string {
+	var lines []string
+	for _, msg := range msgs {
+		line := strings.Join(strings.Fields(msg), " ")
+		if line == "" {
+			continue
+		}
+		if runes := []rune(line); attemptSummaryMaxLength < len(runes) {
+			line = string(runes[:attemptSummaryMaxLength]) + "..."
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "; ")
+}
@@ -0,0 +1,121 @@
+package assert
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/adamluzsi/testcase/internal"
+	"github.com/adamluzsi/testcase/internal/caller"
+	"github.com/adamluzsi/testcase/internal/doubles"
+)
+
+// blockResult is the outcome of an assertion block, executed with a recorder.
+type blockResult struct {
+	// Number is the sequence number of the block, starting from 1.
+	Number   int
+	Location string
+	Passed   bool
+	Messages []string
+}
+
+// blockResults runs and collects the results of assertion blocks.
+// It is safe for concurrent use.
+type blockResults struct {
+	mutex   sync.Mutex
+	count   int
+	results []blockResult
+}
+
+// Run executes the assertion block, and records its result with the location of the caller.
+func (r *blockResults) Run(tb testing.TB, blk func(it It)) blockResult {
+	tb.Helper()
+	result := blockResult{Number: r.next(), Location: callerLocation()}
+	result.Passed, result.Messages = runBlock(tb, blk)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.results = append(r.results, result)
+	return result
+}
+
+func (r *blockResults) next() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.count++
+	return r.count
+}
+
+// Total returns the number of the started assertion blocks.
+func (r *blockResults) Total() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.count
+}
+
+// Filter returns the results with the given outcome, in the order the blocks were started.
+func (r *blockResults) Filter(passed bool) []blockResult {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var results []blockResult
+	for _, result := range r.results {
+		if result.Passed == passed {
+			results = append(results, result)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Number < results[j].Number
+	})
+	return results
+}
+
+// runBlock executes the assertion block with a recorder, so its failures are not reported to the testing.TB.
+func runBlock(tb testing.TB, blk func(it It)) (passed bool, msgs []string) {
+	tb.Helper()
+	recorder := &doubles.RecorderTB{TB: tb}
+	defer recorder.CleanupNow()
+	internal.RecoverGoexit(func() {
+		tb.Helper()
+		blk(MakeIt(recorder))
+	})
+	return !recorder.IsFailed, recorder.Messages()
+}
+
+// formatBlockResults appends the block results to the summary as a numbered list, with their location and messages.
+func formatBlockResults(summary *strings.Builder, results []blockResult) {
+	if len(results) == 0 {
+		return
+	}
+	for _, result := range results {
+		fmt.Fprintf(summary, "\n\n#%d", result.Number)
+		if result.Location != "" {
+			fmt.Fprintf(summary, " %s", result.Location)
+		}
+		for _, msg := range result.Messages {
+			summary.WriteString("\n\t")
+			summary.WriteString(strings.ReplaceAll(msg, "\n", "\n\t"))
+		}
+	}
+}
+
+var assertPkgDirPath = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// callerLocation returns the location of the assertion's caller,
+// skipping the frames of the assert package.
+func callerLocation() string {
+	var location string
+	caller.MatchFrame(func(frame runtime.Frame) bool {
+		if filepath.Dir(frame.File) == assertPkgDirPath && !strings.HasSuffix(frame.File, "_test.go") {
+			return false
+		}
+		location = caller.AsLocation(frame, true)
+		return true
+	})
+	return location
+}
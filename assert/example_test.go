@@ -1,6 +1,7 @@
 package assert_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -101,6 +102,42 @@ func ExampleAsserter_AnyOf() {
 	})
 }
 
+func ExampleAsserter_AllOf() {
+	var tb testing.TB
+	var workers []interface{ Received() bool }
+	assert.Must(tb).AllOf(func(allOf *assert.AllOf) {
+		for _, worker := range workers {
+			allOf.Test(func(it assert.It) {
+				it.Must.True(worker.Received())
+			})
+		}
+	})
+}
+
+func ExampleAsserter_NoneOf() {
+	var tb testing.TB
+	var list []string
+	assert.Must(tb).NoneOf(func(noneOf *assert.NoneOf) {
+		for _, v := range list {
+			noneOf.Test(func(it assert.It) {
+				it.Must.Equal("forbidden", v)
+			})
+		}
+	})
+}
+
+func ExampleAsserter_ExactlyOneOf() {
+	var tb testing.TB
+	var users []struct{ Email string }
+	assert.Must(tb).ExactlyOneOf(func(exactlyOneOf *assert.ExactlyOneOf) {
+		for _, user := range users {
+			exactlyOneOf.Test(func(it assert.It) {
+				it.Must.Equal("foo@example.com", user.Email)
+			})
+		}
+	})
+}
+
 func ExampleAnyOf_listOfInterface() {
 	var tb testing.TB
 	type ExampleInterface interface {
@@ -543,3 +580,48 @@ func ExampleReadAll() {
 	_ = content
 	assert.ReadAll(tb, iotest.ErrReader(errors.New("boom"))) // fail
 }
+
+func ExampleCollect() {
+	var tb testing.TB
+	type Response struct {
+		Code int
+		Body string
+	}
+	var resp Response
+	// every failed assertion in the block is collected,
+	// and reported together as one numbered summary with their caller location.
+	assert.Collect(tb, func(it assert.It) {
+		it.Should.Equal(200, resp.Code)
+		it.Should.Contain(resp.Body, "OK")
+	})
+}
+
+func ExampleBackoff() {
+	var tb testing.TB
+	// retry with exponentially growing interval, until 10 retries or 30 seconds is reached.
+	w := assert.Eventually{RetryStrategy: assert.Backoff{
+		InitialInterval: 10 * time.Millisecond,
+		MaxInterval:     time.Second,
+		Jitter:          0.2,
+		Seed:            42,
+		Count:           10,
+		Timeout:         30 * time.Second,
+	}}
+	w.Assert(tb, func(it assert.It) {
+		it.Must.True(rand.Intn(2) == 0)
+	})
+}
+
+func ExampleErrorAs() {
+	var tb testing.TB
+	var err error
+	var vErr *json.SyntaxError = assert.ErrorAs[*json.SyntaxError](tb, err)
+	assert.Equal(tb, int64(42), vErr.Offset)
+}
+
+func ExampleErrorMatches() {
+	var tb testing.TB
+	err := errors.New("user 42 not found")
+	assert.ErrorMatches(tb, err, `^user \d+ not found$`)
+	assert.ErrorContains(tb, err, "not found")
+}
@@ -80,6 +80,16 @@ func NoError(tb testing.TB, err error, msg ...any) {
 	Must(tb).NoError(err, msg...)
 }
 
+func ErrorContains(tb testing.TB, err error, substr string, msg ...any) {
+	tb.Helper()
+	Must(tb).ErrorContains(err, substr, msg...)
+}
+
+func ErrorMatches(tb testing.TB, err error, pattern string, msg ...any) {
+	tb.Helper()
+	Must(tb).ErrorMatches(err, pattern, msg...)
+}
+
 func Read[T string | []byte](tb testing.TB, expected T, r io.Reader, msg ...any) {
 	tb.Helper()
 	Must(tb).Read(expected, r, msg...)
@@ -89,3 +99,15 @@ func ReadAll(tb testing.TB, r io.Reader, msg ...any) []byte {
 	tb.Helper()
 	return Must(tb).ReadAll(r, msg...)
 }
+
+// Snapshot asserts that the value matches its snapshot (golden file).
+// For more, read the documentation of Asserter.MatchSnapshot.
+func Snapshot(tb testing.TB, name string, value any, msg ...any) {
+	tb.Helper()
+	Must(tb).MatchSnapshot(name, value, msg...)
+}
+
+func Match(tb testing.TB, expected, actual any, msg ...any) {
+	tb.Helper()
+	Must(tb).Match(expected, actual, msg...)
+}
@@ -262,6 +262,36 @@ func TestPublicFunctions(t *testing.T) {
 				assert.NoError(tb, errors.New("boom"))
 			},
 		},
+		// .ErrorContains
+		{
+			Desc:   ".ErrorContains - happy",
+			Failed: false,
+			Assert: func(tb testing.TB) {
+				assert.ErrorContains(tb, errors.New("foo bar baz"), "bar")
+			},
+		},
+		{
+			Desc:   ".ErrorContains - rainy",
+			Failed: true,
+			Assert: func(tb testing.TB) {
+				assert.ErrorContains(tb, errors.New("foo bar baz"), "qux")
+			},
+		},
+		// .ErrorMatches
+		{
+			Desc:   ".ErrorMatches - happy",
+			Failed: false,
+			Assert: func(tb testing.TB) {
+				assert.ErrorMatches(tb, errors.New("user 42 not found"), `^user \d+ not found$`)
+			},
+		},
+		{
+			Desc:   ".ErrorMatches - rainy",
+			Failed: true,
+			Assert: func(tb testing.TB) {
+				assert.ErrorMatches(tb, errors.New("user foo not found"), `^user \d+ not found$`)
+			},
+		},
 		// Read
 		{
 			Desc:   ".Read - happy",
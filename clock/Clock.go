@@ -15,7 +15,7 @@ func Sleep(d time.Duration) {
 
 func After(d time.Duration) <-chan time.Time {
 	startedAt := internal.GetTime()
-	ch := make(chan time.Time)
+	ch := make(chan time.Time, 1)
 	go func() {
 	wait:
 		for {
@@ -0,0 +1,142 @@
+package testcase
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const varDependencyCycleFormat = `Var dependency cycle detected: %s`
+
+// withVarInit returns a *T that should be used to execute the init block of the given variable.
+// The returned *T shares the test runtime with the original one,
+// but it keeps track of which variable's initialisation is in progress,
+// so accessing a variable during its own initialisation can be detected.
+func (t *T) withVarInit(varName string) *T {
+	tcT := *t // pass by value copy
+	tcT.varInitStack = append(append([]string{}, t.varInitStack...), varName)
+	return &tcT
+}
+
+// traceVarAccess registers the access of a variable in the current test's dependency graph.
+// When a variable is accessed during its own initialisation, directly or indirectly,
+// traceVarAccess fails the test with the dependency cycle, instead of deadlocking in variables.Get.
+func (t *T) traceVarAccess(varName string) {
+	t.TB.Helper()
+	for _, c := range t.contexts() {
+		c.vars.markRead(varName)
+	}
+	if len(t.varInitStack) == 0 {
+		return
+	}
+	dependent := t.varInitStack[len(t.varInitStack)-1]
+	t.vars.addDependency(dependent, varName)
+	for _, name := range t.varInitStack {
+		if name != varName {
+			continue
+		}
+		cycle := append(t.vars.dependencyPath(varName, dependent), varName)
+		t.Fatalf(varDependencyCycleFormat, strings.Join(cycle, ` -> `))
+	}
+}
+
+func (v *variables) addDependency(dependent, dependency string) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if _, ok := v.deps[dependent]; !ok {
+		v.deps[dependent] = make(map[string]struct{})
+	}
+	v.deps[dependent][dependency] = struct{}{}
+}
+
+// dependencyPath returns the shortest path in the dependency graph between the two variables.
+func (v *variables) dependencyPath(from, to string) []string {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	var (
+		queue   = []string{from}
+		visited = map[string]string{from: ``}
+	)
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == to {
+			var path []string
+			for name := current; name != ``; name = visited[name] {
+				path = append([]string{name}, path...) // unshift
+			}
+			return path
+		}
+		var next []string
+		for name := range v.deps[current] {
+			next = append(next, name)
+		}
+		sort.Strings(next)
+		for _, name := range next {
+			if _, ok := visited[name]; ok {
+				continue
+			}
+			visited[name] = current
+			queue = append(queue, name)
+		}
+	}
+	return []string{from, to}
+}
+
+func (v *variables) markRead(varName string) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if _, ok := v.defs[varName]; !ok {
+		return
+	}
+	v.reads[varName] = struct{}{}
+}
+
+func (v *variables) isRead(varName string) bool {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	_, ok := v.reads[varName]
+	return ok
+}
+
+// markExecuted marks the Spec and its parents as a scope where a test was executed.
+func (spec *Spec) markExecuted() {
+	for _, s := range spec.specsFromParent() {
+		atomic.StoreInt32(&s.executed, 1)
+	}
+}
+
+func (spec *Spec) isExecuted() bool {
+	return atomic.LoadInt32(&spec.executed) == 1
+}
+
+// unusedLets returns the variables declared in the Spec subtree that no test accessed.
+// Scopes where no test was executed, due to filtering by tags or the -run flag, are not reported.
+func (spec *Spec) unusedLets() []string {
+	var names []string
+	spec.acceptVisitor(visitorFunc(func(s *Spec) {
+		if !s.isExecuted() {
+			return
+		}
+		for name := range s.vars.defs {
+			if !s.vars.isRead(name) {
+				names = append(names, name)
+			}
+		}
+	}))
+	sort.Strings(names)
+	return names
+}
+
+func (spec *Spec) reportUnusedLets(tb testing.TB) {
+	tb.Helper()
+	if tb.Skipped() {
+		return
+	}
+	names := spec.unusedLets()
+	if len(names) == 0 {
+		return
+	}
+	tb.Logf("The following Let declarations were not used by any test:\n\t%s", strings.Join(names, "\n\t"))
+}
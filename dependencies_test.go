@@ -0,0 +1,117 @@
+package testcase_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/doubles"
+	"github.com/adamluzsi/testcase/sandbox"
+)
+
+func TestVar_Get_dependencyCycle(t *testing.T) {
+	t.Run(`direct`, func(tt *testing.T) {
+		it := assert.MakeIt(tt)
+		stub := &doubles.TB{}
+		defer stub.Finish()
+		s := testcase.NewSpec(stub)
+
+		var v testcase.Var[int]
+		v = testcase.Var[int]{
+			ID:   `A`,
+			Init: func(t *testcase.T) int { return v.Get(t) + 1 },
+		}
+
+		s.Test(``, func(t *testcase.T) {
+			out := sandbox.Run(func() { v.Get(t) })
+			it.Must.False(out.OK)
+		})
+		s.Finish()
+		it.Must.True(stub.IsFailed)
+		it.Must.Contain(stub.Logs.String(), `A -> A`)
+	})
+
+	t.Run(`indirect`, func(tt *testing.T) {
+		it := assert.MakeIt(tt)
+		stub := &doubles.TB{}
+		defer stub.Finish()
+		s := testcase.NewSpec(stub)
+
+		var a, b, c testcase.Var[int]
+		a = testcase.Let(s, func(t *testcase.T) int { return b.Get(t) })
+		b = testcase.Let(s, func(t *testcase.T) int { return c.Get(t) })
+		c = testcase.Let(s, func(t *testcase.T) int { return a.Get(t) })
+
+		s.Test(``, func(t *testcase.T) {
+			out := sandbox.Run(func() { a.Get(t) })
+			it.Must.False(out.OK)
+		})
+		s.Finish()
+		it.Must.True(stub.IsFailed)
+		it.Must.Contain(stub.Logs.String(), fmt.Sprintf(`%s -> %s -> %s -> %s`, a.ID, b.ID, c.ID, a.ID))
+	})
+
+	t.Run(`dependencies without cycle`, func(tt *testing.T) {
+		it := assert.MakeIt(tt)
+		stub := &doubles.TB{}
+		defer stub.Finish()
+		s := testcase.NewSpec(stub)
+
+		a := testcase.Let(s, func(t *testcase.T) int { return 1 })
+		b := testcase.Let(s, func(t *testcase.T) int { return a.Get(t) + 1 })
+		c := testcase.Let(s, func(t *testcase.T) int { return a.Get(t) + b.Get(t) })
+
+		s.Test(``, func(t *testcase.T) {
+			t.Must.Equal(3, c.Get(t))
+		})
+		s.Finish()
+		it.Must.False(stub.IsFailed)
+	})
+}
+
+func TestReportUnusedLet(t *testing.T) {
+	it := assert.MakeIt(t)
+	stub := &doubles.TB{}
+	s := testcase.NewSpec(stub, testcase.ReportUnusedLet())
+
+	used := testcase.Var[int]{ID: `used`, Init: func(t *testcase.T) int { return 42 }}.Bind(s)
+	usedByDependency := testcase.Var[int]{ID: `used by dependency`, Init: func(t *testcase.T) int { return 42 }}.Bind(s)
+	dependent := testcase.Var[int]{ID: `dependent`, Init: func(t *testcase.T) int { return usedByDependency.Get(t) }}.Bind(s)
+	testcase.Var[int]{ID: `unused`, Init: func(t *testcase.T) int { return 42 }}.Bind(s)
+
+	s.Context(`sub`, func(s *testcase.Spec) {
+		testcase.Var[int]{ID: `unused in sub context`, Init: func(t *testcase.T) int { return 42 }}.Bind(s)
+
+		s.Test(``, func(t *testcase.T) { _ = dependent.Get(t) })
+	})
+
+	s.Context(`not executed`, func(s *testcase.Spec) {
+		testcase.Var[int]{ID: `declared in a context without executed tests`, Init: func(t *testcase.T) int { return 42 }}.Bind(s)
+	})
+
+	s.Test(``, func(t *testcase.T) { _ = used.Get(t) })
+
+	s.Finish()
+	stub.Finish()
+
+	logs := stub.Logs.String()
+	it.Must.Contain(logs, `The following Let declarations were not used by any test`)
+	it.Must.Contain(logs, "\tunused\n")
+	it.Must.Contain(logs, "\tunused in sub context")
+	it.Must.NotContain(logs, "\tused\n")
+	it.Must.NotContain(logs, "\tused by dependency")
+	it.Must.NotContain(logs, "\tdependent")
+	it.Must.NotContain(logs, "declared in a context without executed tests")
+}
+
+func TestReportUnusedLet_whenAllLetIsUsed(t *testing.T) {
+	it := assert.MakeIt(t)
+	stub := &doubles.TB{}
+	s := testcase.NewSpec(stub, testcase.ReportUnusedLet())
+	v := testcase.Let(s, func(t *testcase.T) int { return 42 })
+	s.Test(``, func(t *testcase.T) { _ = v.Get(t) })
+	s.Finish()
+	stub.Finish()
+	it.Must.NotContain(stub.Logs.String(), `were not used`)
+}
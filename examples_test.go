@@ -1325,6 +1325,38 @@ func ExampleTableTest_classicInlined() {
 	})
 }
 
+func ExampleTableTestCases() {
+	var tb testing.TB
+	myFunc := func(in int) string {
+		if in == 42 {
+			return "The Answer"
+		}
+		return "Not the answer"
+	}
+	type Case struct {
+		Input    int
+		Expected string
+	}
+	testcase.TableTestCases(tb, testcase.Cases[Case]{
+		{
+			Name: "when the input is correct",
+			Case: Case{Input: 42, Expected: "The Answer"},
+		},
+		{
+			Name:    "when the input is incorrect",
+			Case:    Case{Input: 24, Expected: "Not the answer"},
+			Options: []testcase.SpecOption{testcase.Tag("negative")},
+		},
+		{
+			Name:    "when the input is too large",
+			Case:    Case{Input: 1 << 30, Expected: "Not the answer"},
+			Options: []testcase.SpecOption{testcase.Skip("overflow is not handled yet")},
+		},
+	}, func(t *testcase.T, c Case) {
+		t.Must.Equal(c.Expected, myFunc(c.Input))
+	})
+}
+
 func ExampleTableTest_classicStructured() {
 	var tb testing.TB
 	myFunc := func(in int) string {
@@ -0,0 +1,54 @@
+package caller
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+)
+
+var goroutinePrefix = []byte("goroutine ")
+
+// GoroutineID returns the id of the current goroutine, parsed from the header of its stack trace.
+func GoroutineID() int64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	id, ok := parseGoroutineID(buf[:n])
+	if !ok {
+		return -1
+	}
+	return id
+}
+
+// GoroutineStack returns the stack trace of the goroutine with the given id.
+func GoroutineStack(id int64) (string, bool) {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		if gid, ok := parseGoroutineID(stack); ok && gid == id {
+			return string(stack), true
+		}
+	}
+	return "", false
+}
+
+func parseGoroutineID(stack []byte) (int64, bool) {
+	if !bytes.HasPrefix(stack, goroutinePrefix) {
+		return 0, false
+	}
+	b := bytes.TrimPrefix(stack, goroutinePrefix)
+	if i := bytes.IndexByte(b, ' '); 0 <= i {
+		b = b[:i]
+	}
+	id, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
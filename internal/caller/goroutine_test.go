@@ -0,0 +1,36 @@
+package caller_test
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/caller"
+)
+
+func TestGoroutineID(t *testing.T) {
+	id := caller.GoroutineID()
+	assert.True(t, 0 < id)
+	assert.Equal(t, id, caller.GoroutineID())
+
+	other := make(chan int64)
+	go func() { other <- caller.GoroutineID() }()
+	assert.NotEqual(t, id, <-other)
+}
+
+func TestGoroutineStack(t *testing.T) {
+	var (
+		gid     = make(chan int64)
+		release = make(chan struct{})
+	)
+	defer close(release)
+	go func() {
+		gid <- caller.GoroutineID()
+		<-release
+	}()
+	stack, ok := caller.GoroutineStack(<-gid)
+	assert.True(t, ok)
+	assert.Contain(t, stack, `goroutine_test.go`)
+
+	_, ok = caller.GoroutineStack(-1)
+	assert.False(t, ok)
+}
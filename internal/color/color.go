@@ -0,0 +1,77 @@
+package color
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// EnvKey is the environment variable key that controls the colouring of the failure outputs.
+//   - auto (default): colour is used when the output is a terminal
+//   - always: colour is always used
+//   - never: plain text is used
+const EnvKey = `TESTCASE_COLOR`
+
+const (
+	ModeAuto   = "auto"
+	ModeAlways = "always"
+	ModeNever  = "never"
+)
+
+type Code string
+
+const (
+	Bold   Code = "1"
+	Red    Code = "31"
+	Green  Code = "32"
+	Yellow Code = "33"
+	Cyan   Code = "36"
+)
+
+const reset = "\x1b[0m"
+
+// Enabled reports whether the output should be coloured, based on the TESTCASE_COLOR environment variable.
+func Enabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(EnvKey))) {
+	case ModeAlways:
+		return true
+	case ModeNever:
+		return false
+	default:
+		return isTerminal()
+	}
+}
+
+// Paint wraps the text into the ANSI escape sequence of the codes.
+// When colouring is not enabled or the text is empty, the text is returned as is.
+func Paint(text string, codes ...Code) string {
+	if text == "" || len(codes) == 0 || !Enabled() {
+		return text
+	}
+	var seq strings.Builder
+	seq.WriteString("\x1b[")
+	for i, code := range codes {
+		if 0 < i {
+			seq.WriteString(";")
+		}
+		seq.WriteString(string(code))
+	}
+	seq.WriteString("m")
+	return seq.String() + text + reset
+}
+
+var terminal struct {
+	once sync.Once
+	ok   bool
+}
+
+func isTerminal() bool {
+	terminal.once.Do(func() {
+		if _, ok := os.LookupEnv("NO_COLOR"); ok || os.Getenv("TERM") == "dumb" {
+			return
+		}
+		info, err := os.Stdout.Stat()
+		terminal.ok = err == nil && info.Mode()&os.ModeCharDevice != 0
+	})
+	return terminal.ok
+}
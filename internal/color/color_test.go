@@ -0,0 +1,28 @@
+package color_test
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/color"
+)
+
+func TestEnabled(t *testing.T) {
+	t.Setenv(color.EnvKey, color.ModeAlways)
+	assert.True(t, color.Enabled())
+	t.Setenv(color.EnvKey, color.ModeNever)
+	assert.False(t, color.Enabled())
+}
+
+func TestPaint(t *testing.T) {
+	t.Run("when colouring is enabled, text is wrapped into the escape sequence", func(t *testing.T) {
+		t.Setenv(color.EnvKey, color.ModeAlways)
+		assert.Equal(t, "\x1b[31mfoo\x1b[0m", color.Paint("foo", color.Red))
+		assert.Equal(t, "\x1b[1;32mfoo\x1b[0m", color.Paint("foo", color.Bold, color.Green))
+		assert.Equal(t, "", color.Paint("", color.Red))
+	})
+	t.Run("when colouring is disabled, text is returned as is", func(t *testing.T) {
+		t.Setenv(color.EnvKey, color.ModeNever)
+		assert.Equal(t, "foo", color.Paint("foo", color.Red))
+	})
+}
@@ -1,8 +1,10 @@
 package doubles
 
 import (
+	"fmt"
 	"github.com/adamluzsi/testcase/internal/env"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
 
@@ -24,6 +26,7 @@ type RecorderTB struct {
 
 type record struct {
 	Skip    bool
+	Message string
 	Forward func()
 	Mimic   func()
 	Ensure  func()
@@ -61,6 +64,19 @@ func (rtb *RecorderTB) Forward() {
 	}
 }
 
+// Messages returns the recorded log and failure messages.
+func (rtb *RecorderTB) Messages() []string {
+	rtb.recordsMutex.Lock()
+	defer rtb.recordsMutex.Unlock()
+	var msgs []string
+	for _, record := range rtb.records {
+		if record.Message != "" {
+			msgs = append(msgs, record.Message)
+		}
+	}
+	return msgs
+}
+
 func (rtb *RecorderTB) CleanupNow() {
 	defer rtb.withPassthrough()()
 	td := &teardown.Teardown{}
@@ -122,6 +138,7 @@ func (rtb *RecorderTB) Helper() {
 
 func (rtb *RecorderTB) Log(args ...interface{}) {
 	rtb.record(func(r *record) {
+		r.Message = sprintln(args...)
 		r.Forward = func() {
 			rtb.TB.Helper()
 			rtb.TB.Log(args...)
@@ -131,6 +148,7 @@ func (rtb *RecorderTB) Log(args ...interface{}) {
 
 func (rtb *RecorderTB) Logf(format string, args ...interface{}) {
 	rtb.record(func(r *record) {
+		r.Message = fmt.Sprintf(format, args...)
 		r.Forward = func() {
 			rtb.TB.Helper()
 			rtb.TB.Logf(format, args...)
@@ -174,6 +192,7 @@ func (rtb *RecorderTB) FailNow() {
 
 func (rtb *RecorderTB) Error(args ...interface{}) {
 	rtb.record(func(r *record) {
+		r.Message = sprintln(args...)
 		r.Forward = func() {
 			rtb.TB.Helper()
 			rtb.TB.Error(args...)
@@ -184,6 +203,7 @@ func (rtb *RecorderTB) Error(args ...interface{}) {
 
 func (rtb *RecorderTB) Errorf(format string, args ...interface{}) {
 	rtb.record(func(r *record) {
+		r.Message = fmt.Sprintf(format, args...)
 		r.Forward = func() {
 			rtb.TB.Helper()
 			rtb.TB.Errorf(format, args...)
@@ -194,6 +214,7 @@ func (rtb *RecorderTB) Errorf(format string, args ...interface{}) {
 
 func (rtb *RecorderTB) Fatal(args ...interface{}) {
 	rtb.record(func(r *record) {
+		r.Message = sprintln(args...)
 		r.Forward = func() {
 			rtb.TB.Helper()
 			rtb.TB.Fatal(args...)
@@ -208,6 +229,7 @@ func (rtb *RecorderTB) Fatal(args ...interface{}) {
 
 func (rtb *RecorderTB) Fatalf(format string, args ...interface{}) {
 	rtb.record(func(r *record) {
+		r.Message = fmt.Sprintf(format, args...)
 		r.Forward = func() {
 			rtb.TB.Helper()
 			rtb.TB.Fatalf(format, args...)
@@ -238,3 +260,7 @@ func (rtb *RecorderTB) Failed() bool {
 func (rtb *RecorderTB) Setenv(key, value string) {
 	env.SetEnv(rtb, key, value)
 }
+
+func sprintln(args ...interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+}
@@ -574,6 +574,29 @@ func TestRecorderTB(t *testing.T) {
 		})
 	})
 
+	s.Describe(`.Messages`, func(s *testcase.Spec) {
+		var subject = func(t *testcase.T) []string {
+			return recorder.Get(t).Messages()
+		}
+
+		s.Then(`by default, it has no messages`, func(t *testcase.T) {
+			t.Must.Empty(subject(t))
+		})
+
+		s.When(`logs and failures are recorded`, func(s *testcase.Spec) {
+			s.Before(func(t *testcase.T) {
+				recorder.Get(t).Log("foo", 42)
+				recorder.Get(t).Errorf("bar %d", 24)
+				recorder.Get(t).Fail()
+				sandbox.Run(func() { recorder.Get(t).Fatal("baz") })
+			})
+
+			s.Then(`it returns the messages in the order of recording`, func(t *testcase.T) {
+				t.Must.Equal([]string{"foo 42", "bar 24", "baz"}, subject(t))
+			})
+		})
+	})
+
 	s.Describe(`.Forward`, func(s *testcase.Spec) {
 		var subject = func(t *testcase.T) {
 			recorder.Get(t).Forward()
@@ -0,0 +1,10 @@
+package mycontainer
+
+// Box is an example generic type.
+type Box[T any] struct {
+	Value T
+}
+
+type Item struct {
+	Name string
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/adamluzsi/testcase/internal/color"
 	"github.com/adamluzsi/testcase/pp"
 )
 
@@ -19,32 +20,38 @@ type Value struct {
 	Value interface{}
 }
 
+// Formatted is a Value that is already formatted with pp.Format, thus it is printed as it is.
+type Formatted string
+
 func (m Message) String() string {
 	var (
 		format string
 		args   []interface{}
 	)
 	if m.Method != "" {
-		format += "[%s] "
-		args = append(args, m.Method)
+		format += "%s "
+		args = append(args, color.Paint("["+m.Method+"]", color.Bold))
 	}
 	if m.Cause != "" {
 		format += "%s"
-		args = append(args, m.Cause)
+		args = append(args, color.Paint(m.Cause, color.Bold))
 	}
 	if 0 < len(m.Message) {
 		format += "\n%s"
 		args = append(args, strings.TrimSpace(fmt.Sprintln(m.Message...)))
 	}
 	for _, v := range m.Values {
-		value := pp.Format(v.Value)
+		value, ok := v.Value.(Formatted)
+		if !ok {
+			value = Formatted(pp.Format(v.Value))
+		}
 		format += "\n%s:"
-		if 0 < strings.Count(value, "\n") {
+		if 0 < strings.Count(string(value), "\n") {
 			format += "\n\n%s\n"
 		} else {
 			format += "\t%s"
 		}
-		args = append(args, m.rightAlign(v.Label), value)
+		args = append(args, color.Paint(m.rightAlign(v.Label), color.Cyan), color.Paint(string(value), valueColor(v.Label)...))
 	}
 	return fmt.Sprintf(format, args...)
 }
@@ -65,3 +72,15 @@ func (m Message) labelLength() int {
 	}
 	return maxLength
 }
+
+// valueColor highlights the expected and the actual values, so they can be told apart at a glance.
+func valueColor(label string) []color.Code {
+	switch strings.TrimSpace(strings.TrimSuffix(label, ":")) {
+	case "expected":
+		return []color.Code{color.Green}
+	case "actual":
+		return []color.Code{color.Red}
+	default:
+		return nil
+	}
+}
@@ -1,9 +1,11 @@
 package fmterror_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/color"
 	"github.com/adamluzsi/testcase/internal/fmterror"
 	"github.com/adamluzsi/testcase/pp"
 )
@@ -108,6 +110,17 @@ func TestMessage_String(t *testing.T) {
 			},
 			Expected: "\nfoo:\n\n" + pp.Format([]int{1, 2, 3}) + "\n",
 		},
+		{
+			Message: fmterror.Message{
+				Values: []fmterror.Value{
+					{
+						Label: "foo",
+						Value: fmterror.Formatted(pp.Format("bar")),
+					},
+				},
+			},
+			Expected: "\nfoo:\t\"bar\"",
+		},
 	} {
 		tc := tc
 		t.Run(``, func(t *testing.T) {
@@ -116,3 +129,25 @@ func TestMessage_String(t *testing.T) {
 		})
 	}
 }
+
+func TestMessage_String_color(t *testing.T) {
+	msg := fmterror.Message{
+		Method:  "Equal",
+		Cause:   "Values are different.",
+		Message: []any{"custom message"},
+		Values: []fmterror.Value{
+			{Label: "expected", Value: 42},
+			{Label: "actual", Value: 24},
+		},
+	}
+	t.Setenv(color.EnvKey, color.ModeNever)
+	plain := msg.String()
+	assert.NotContain(t, plain, "\x1b[")
+
+	t.Setenv(color.EnvKey, color.ModeAlways)
+	colored := msg.String()
+	assert.Contain(t, colored, color.Paint("42", color.Green))
+	assert.Contain(t, colored, color.Paint("24", color.Red))
+	assert.Contain(t, colored, color.Paint("[Equal]", color.Bold))
+	assert.Equal(t, plain, regexp.MustCompile("\x1b\\[[0-9;]*m").ReplaceAllString(colored, ""))
+}
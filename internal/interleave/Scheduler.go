@@ -0,0 +1,221 @@
+package interleave
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/adamluzsi/testcase/internal/caller"
+)
+
+// DefaultStallTimeout is the StallTimeout of a Scheduler when it is not configured.
+const DefaultStallTimeout = 25 * time.Millisecond
+
+// Yield is a scheduling point.
+// When the calling goroutine is a participant of a running Scheduler,
+// it hands over the turn to a participant picked by the Scheduler's seeded random source.
+// Otherwise, it is a no-op.
+func Yield() {
+	if atomic.LoadInt32(&registry.active) == 0 {
+		return
+	}
+	p, ok := registry.lookup(caller.GoroutineID())
+	if !ok {
+		return
+	}
+	p.scheduler.yield(p)
+}
+
+var registry = &participantRegistry{participants: make(map[int64]*participant)}
+
+// participantRegistry maps the goroutines to the participants of the running schedulers.
+type participantRegistry struct {
+	active       int32
+	mutex        sync.RWMutex
+	participants map[int64]*participant
+}
+
+func (r *participantRegistry) lookup(gid int64) (*participant, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	p, ok := r.participants[gid]
+	return p, ok
+}
+
+func (r *participantRegistry) register(gid int64, p *participant) func() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.participants[gid] = p
+	return func() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		delete(r.participants, gid)
+	}
+}
+
+// Scheduler executes participant functions in their own goroutines, but only one of them at a time.
+// The participants hand over the turn to each other at the yield points,
+// and the next participant is picked with a seeded random source,
+// thus the same seed results in the same interleaving.
+type Scheduler struct {
+	// StallTimeout is the duration after which a participant that neither yielded nor finished
+	// is considered stalled, and the turn is given to another participant.
+	// Without this, a participant that waits for a lock held by a paused participant would block forever.
+	//
+	// A stall handover makes the run non-deterministic,
+	// since the stalled participant may continue concurrently with the next one.
+	// The stalled participants are reported by Stalls.
+	// When it is zero, DefaultStallTimeout is used.
+	StallTimeout time.Duration
+
+	rnd *rand.Rand
+
+	mutex        sync.Mutex
+	participants []*participant
+	current      *participant
+	epoch        uint64
+	stalls       []int
+}
+
+type participant struct {
+	scheduler *Scheduler
+	index     int
+	state     participantState
+	turn      chan struct{}
+}
+
+type participantState int
+
+const (
+	waiting participantState = iota
+	running
+	done
+)
+
+func NewScheduler(seed int64) *Scheduler {
+	return &Scheduler{rnd: rand.New(rand.NewSource(seed))}
+}
+
+// Run executes the functions in the seeded interleaving, and waits until all of them are finished.
+// It returns the number of functions which returned normally, without a panic or runtime.Goexit.
+func (s *Scheduler) Run(fns []func()) int {
+	var (
+		wg       sync.WaitGroup
+		ready    sync.WaitGroup
+		finished int32
+	)
+	atomic.AddInt32(&registry.active, 1)
+	defer atomic.AddInt32(&registry.active, -1)
+	s.participants = make([]*participant, len(fns))
+	wg.Add(len(fns))
+	ready.Add(len(fns))
+	for i, fn := range fns {
+		p := &participant{scheduler: s, index: i, turn: make(chan struct{}, 1)}
+		s.participants[i] = p
+		go func(p *participant, blk func()) {
+			defer wg.Done()
+			defer registry.register(caller.GoroutineID(), p)()
+			defer s.finish(p)
+			ready.Done()
+			<-p.turn
+			blk()
+			atomic.AddInt32(&finished, 1)
+		}(p, fn)
+	}
+	ready.Wait()
+	stop := s.watch()
+	s.mutex.Lock()
+	s.handover()
+	s.mutex.Unlock()
+	wg.Wait()
+	stop()
+	return int(finished)
+}
+
+// Stalls returns the indexes of the participants which stalled during the run, in the order of the stalls.
+// When it is not empty, the interleaving of the run is not guaranteed to be reproducible with the same seed.
+func (s *Scheduler) Stalls() []int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]int(nil), s.stalls...)
+}
+
+func (s *Scheduler) yield(p *participant) {
+	s.mutex.Lock()
+	p.state = waiting
+	if s.current == p || s.current == nil {
+		s.handover()
+	}
+	s.mutex.Unlock()
+	<-p.turn
+}
+
+func (s *Scheduler) finish(p *participant) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p.state = done
+	if s.current == p || s.current == nil {
+		s.handover()
+	}
+}
+
+// handover gives the turn to a randomly picked waiting participant.
+// The caller must hold the mutex.
+func (s *Scheduler) handover() {
+	s.epoch++
+	var candidates []*participant
+	for _, p := range s.participants {
+		if p.state == waiting {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		s.current = nil
+		return
+	}
+	next := candidates[s.rnd.Intn(len(candidates))]
+	next.state = running
+	s.current = next
+	next.turn <- struct{}{}
+}
+
+func (s *Scheduler) stallTimeout() time.Duration {
+	if s.StallTimeout <= 0 {
+		return DefaultStallTimeout
+	}
+	return s.StallTimeout
+}
+
+// watch detects when the current participant is stalled, records it, and lets another participant continue.
+func (s *Scheduler) watch() func() {
+	var (
+		quit = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(s.stallTimeout())
+		defer ticker.Stop()
+		var lastEpoch uint64
+		for {
+			select {
+			case <-quit:
+				return
+			case <-ticker.C:
+				s.mutex.Lock()
+				if s.current != nil && s.epoch == lastEpoch {
+					s.stalls = append(s.stalls, s.current.index)
+					s.handover()
+				}
+				lastEpoch = s.epoch
+				s.mutex.Unlock()
+			}
+		}
+	}()
+	return func() {
+		close(quit)
+		wg.Wait()
+	}
+}
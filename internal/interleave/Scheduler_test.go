@@ -0,0 +1,99 @@
+package interleave_test
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/interleave"
+)
+
+func recordInterleaving(seed int64) []string {
+	var (
+		mutex  sync.Mutex
+		events []string
+	)
+	participant := func(name string) func() {
+		return func() {
+			for i := 0; i < 5; i++ {
+				mutex.Lock()
+				events = append(events, name)
+				mutex.Unlock()
+				interleave.Yield()
+			}
+		}
+	}
+	interleave.NewScheduler(seed).Run([]func(){participant("a"), participant("b"), participant("c")})
+	return events
+}
+
+func TestScheduler_Run(t *testing.T) {
+	t.Run("the same seed results in the same interleaving", func(t *testing.T) {
+		assert.Equal(t, recordInterleaving(42), recordInterleaving(42))
+	})
+
+	t.Run("different seeds explore different interleavings", func(t *testing.T) {
+		interleavings := make(map[string]struct{})
+		for seed := int64(0); seed < 16; seed++ {
+			var key string
+			for _, e := range recordInterleaving(seed) {
+				key += e
+			}
+			interleavings[key] = struct{}{}
+		}
+		assert.True(t, 1 < len(interleavings))
+	})
+
+	t.Run("the number of functions that returned is reported", func(t *testing.T) {
+		n := interleave.NewScheduler(0).Run([]func(){
+			func() { interleave.Yield() },
+			func() { runtime.Goexit() },
+		})
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("a participant blocked by another paused participant does not block the run", func(t *testing.T) {
+		var m sync.Mutex
+		n := interleave.NewScheduler(0).Run([]func(){
+			func() {
+				m.Lock()
+				interleave.Yield()
+				m.Unlock()
+			},
+			func() {
+				m.Lock()
+				interleave.Yield()
+				m.Unlock()
+			},
+		})
+		assert.Equal(t, 2, n)
+	})
+
+	t.Run("a stalled participant is reported", func(t *testing.T) {
+		s := interleave.NewScheduler(0)
+		s.StallTimeout = time.Millisecond
+		n := s.Run([]func(){
+			func() { time.Sleep(10 * time.Millisecond) },
+			func() { time.Sleep(10 * time.Millisecond) },
+		})
+		assert.Equal(t, 2, n)
+		assert.NotEmpty(t, s.Stalls())
+	})
+
+	t.Run("when every participant yields in time, no stall is reported", func(t *testing.T) {
+		s := interleave.NewScheduler(0)
+		s.StallTimeout = time.Minute
+		n := s.Run([]func(){
+			func() { interleave.Yield() },
+			func() { interleave.Yield() },
+		})
+		assert.Equal(t, 2, n)
+		assert.Empty(t, s.Stalls())
+	})
+}
+
+func TestYield_outsideOfScheduler(t *testing.T) {
+	interleave.Yield() // no-op
+}
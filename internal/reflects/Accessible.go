@@ -0,0 +1,26 @@
+package reflects
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// Accessible returns a reflect.Value which can be used with reflect.Value#Interface,
+// even if the value was obtained through an unexported struct field.
+// Values of unexported fields are only accessible when their struct is addressable, see Addressable.
+func Accessible(rv reflect.Value) reflect.Value {
+	if !rv.CanInterface() && rv.CanAddr() {
+		return reflect.NewAt(rv.Type(), unsafe.Pointer(rv.UnsafeAddr())).Elem()
+	}
+	return rv
+}
+
+// Addressable returns an addressable copy of the value, unless it is already addressable.
+func Addressable(rv reflect.Value) reflect.Value {
+	if rv.CanAddr() {
+		return rv
+	}
+	ptr := reflect.New(rv.Type())
+	ptr.Elem().Set(rv)
+	return ptr.Elem()
+}
@@ -0,0 +1,30 @@
+package reflects_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/reflects"
+)
+
+func TestAccessible(t *testing.T) {
+	type T struct{ unexported string }
+
+	rv := reflects.Addressable(reflect.ValueOf(T{unexported: "foo"}))
+	field := rv.FieldByName("unexported")
+	assert.False(t, field.CanInterface())
+	field = reflects.Accessible(field)
+	assert.True(t, field.CanInterface())
+	assert.Equal[any](t, "foo", field.Interface())
+}
+
+func TestAddressable(t *testing.T) {
+	rv := reflect.ValueOf(42)
+	assert.False(t, rv.CanAddr())
+	rv = reflects.Addressable(rv)
+	assert.True(t, rv.CanAddr())
+	assert.Equal[any](t, 42, rv.Interface())
+
+	assert.Equal(t, rv.Addr().Pointer(), reflects.Addressable(rv).Addr().Pointer())
+}
@@ -0,0 +1,114 @@
+package reflects
+
+import (
+	"reflect"
+	"time"
+	"unsafe"
+)
+
+// DeepCopy returns a deep copy of the value.
+// Pointers, maps, slices, arrays and structs, including their unexported fields, are copied recursively,
+// while channels, functions and unsafe pointers are kept as they are.
+// Cyclic references are preserved in the copy.
+func DeepCopy[T any](v T) T {
+	rv := reflect.ValueOf(&v).Elem()
+	out := reflect.New(rv.Type()).Elem()
+	deepCopy(out, rv, make(map[uintptr]reflect.Value))
+	return *out.Addr().Interface().(*T)
+}
+
+var typeTime = reflect.TypeOf(time.Time{})
+
+func deepCopy(dst, src reflect.Value, visited map[uintptr]reflect.Value) {
+	if !src.CanInterface() && src.CanAddr() { // unexported struct field
+		src = reflect.NewAt(src.Type(), unsafe.Pointer(src.UnsafeAddr())).Elem()
+	}
+	if src.Type() == typeTime {
+		setValue(dst, src)
+		return
+	}
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		if ptr, ok := visited[src.Pointer()]; ok {
+			setValue(dst, ptr)
+			return
+		}
+		ptr := reflect.New(src.Type().Elem())
+		visited[src.Pointer()] = ptr
+		deepCopy(ptr.Elem(), src.Elem(), visited)
+		setValue(dst, ptr)
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := reflect.New(src.Elem().Type()).Elem()
+		deepCopy(elem, addressable(src.Elem()), visited)
+		setValue(dst, elem)
+
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		if m, ok := visited[src.Pointer()]; ok {
+			setValue(dst, m)
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		visited[src.Pointer()] = m
+		iter := src.MapRange()
+		for iter.Next() {
+			key := reflect.New(src.Type().Key()).Elem()
+			deepCopy(key, addressable(iter.Key()), visited)
+			value := reflect.New(src.Type().Elem()).Elem()
+			deepCopy(value, addressable(iter.Value()), visited)
+			m.SetMapIndex(key, value)
+		}
+		setValue(dst, m)
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		slice := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		for i, l := 0, src.Len(); i < l; i++ {
+			deepCopy(slice.Index(i), src.Index(i), visited)
+		}
+		setValue(dst, slice)
+
+	case reflect.Array:
+		for i, l := 0, src.Len(); i < l; i++ {
+			deepCopy(dst.Index(i), src.Index(i), visited)
+		}
+
+	case reflect.Struct:
+		for i, l := 0, src.NumField(); i < l; i++ {
+			deepCopy(dst.Field(i), src.Field(i), visited)
+		}
+
+	default:
+		setValue(dst, src)
+	}
+}
+
+// addressable copies a non-addressable value, like an interface's or a map's value, into an addressable temporary,
+// so its unexported struct fields can be accessed during the copy.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	tmp := reflect.New(v.Type()).Elem()
+	tmp.Set(v)
+	return tmp
+}
+
+// setValue sets the value of dst, even if it is an unexported struct field.
+func setValue(dst, src reflect.Value) {
+	if !dst.CanSet() {
+		dst = reflect.NewAt(dst.Type(), unsafe.Pointer(dst.UnsafeAddr())).Elem()
+	}
+	dst.Set(src)
+}
@@ -0,0 +1,108 @@
+package reflects_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/reflects"
+)
+
+func TestDeepCopy(t *testing.T) {
+	type Node struct {
+		Value    int
+		Next     *Node
+		Tags     map[string][]string
+		List     []int
+		Array    [2]*int
+		Any      any
+		Time     time.Time
+		Fn       func()
+		internal map[string]int
+	}
+
+	t.Run(`primitive`, func(t *testing.T) {
+		assert.Equal(t, 42, reflects.DeepCopy(42))
+		assert.Equal(t, "foo", reflects.DeepCopy("foo"))
+	})
+
+	t.Run(`nil values`, func(t *testing.T) {
+		assert.Nil(t, reflects.DeepCopy[*Node](nil))
+		assert.Nil(t, reflects.DeepCopy[map[string]int](nil))
+		assert.Nil(t, reflects.DeepCopy[any](nil))
+	})
+
+	t.Run(`map`, func(t *testing.T) {
+		og := map[string][]int{"foo": {1, 2}}
+		cp := reflects.DeepCopy(og)
+		assert.Equal(t, og, cp)
+		cp["foo"][0] = 42
+		cp["bar"] = []int{3}
+		assert.Equal(t, map[string][]int{"foo": {1, 2}}, og)
+	})
+
+	t.Run(`struct with references`, func(t *testing.T) {
+		n := 42
+		now := time.Now()
+		og := &Node{
+			Value:    1,
+			Next:     &Node{Value: 2},
+			Tags:     map[string][]string{"foo": {"bar"}},
+			List:     []int{1, 2, 3},
+			Array:    [2]*int{&n, nil},
+			Any:      &Node{Value: 3},
+			Time:     now,
+			internal: map[string]int{"foo": 1},
+		}
+		cp := reflects.DeepCopy(og)
+		assert.Equal(t, og, cp)
+
+		assert.True(t, og != cp)
+		assert.True(t, og.Next != cp.Next)
+		assert.True(t, og.Array[0] != cp.Array[0])
+		assert.True(t, og.Any.(*Node) != cp.Any.(*Node))
+		assert.True(t, now.Equal(cp.Time))
+		assert.Equal(t, now.Location(), cp.Time.Location())
+
+		cp.Tags["foo"][0] = "baz"
+		cp.List[0] = 42
+		cp.internal["foo"] = 42
+		*cp.Array[0] = 24
+		assert.Equal(t, "bar", og.Tags["foo"][0])
+		assert.Equal(t, 1, og.List[0])
+		assert.Equal(t, 1, og.internal["foo"])
+		assert.Equal(t, 42, n)
+	})
+
+	t.Run(`cyclic reference`, func(t *testing.T) {
+		og := &Node{Value: 1}
+		og.Next = og
+		cp := reflects.DeepCopy(og)
+		assert.True(t, og != cp)
+		assert.True(t, cp.Next == cp)
+	})
+
+	type inner struct {
+		a int
+		m map[string]int
+	}
+
+	t.Run(`interface holding a struct with unexported fields`, func(t *testing.T) {
+		var og any = inner{a: 1, m: map[string]int{"foo": 1}}
+		cp := reflects.DeepCopy(og)
+		assert.Equal(t, og, cp)
+		cp.(inner).m["foo"] = 42
+		assert.Equal(t, 1, og.(inner).m["foo"])
+	})
+
+	t.Run(`map with struct values with unexported fields`, func(t *testing.T) {
+		type Holder struct {
+			Values map[string]inner
+		}
+		og := Holder{Values: map[string]inner{"foo": {a: 1, m: map[string]int{"bar": 2}}}}
+		cp := reflects.DeepCopy(og)
+		assert.Equal(t, og, cp)
+		cp.Values["foo"].m["bar"] = 42
+		assert.Equal(t, 2, og.Values["foo"].m["bar"])
+	})
+}
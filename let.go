@@ -58,8 +58,11 @@ func let[V any](spec *Spec, varName string, blk VarInitFunc[V]) Var[V] {
 		spec.testingTB.Fatalf(warnEventOnImmutableFormat, `Let`)
 	}
 	if blk != nil {
-		spec.vars.sdefs[varName] = findCurrentDeclsFor(spec, varName)
+		decls := findCurrentDeclsFor(spec, varName)
+		spec.vars.mutex.Lock()
+		spec.vars.sdefs[varName] = decls
 		spec.vars.defs[varName] = func(t *T) any { return blk(t) }
+		spec.vars.mutex.Unlock()
 	}
 	return Var[V]{ID: varName, Init: blk}
 }
@@ -72,6 +72,49 @@ func SkipBenchmark() SpecOption {
 	})
 }
 
+// ReportAllocs is a SpecOption that enables malloc statistics for the benchmarks of the spec/testCase.
+// It has the same effect as calling testing.B#ReportAllocs, and it is ignored during testing.
+func ReportAllocs() SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.reportAllocs = true
+	})
+}
+
+// SetBytes is a SpecOption that records the number of bytes processed in a single operation
+// by the benchmarks of the spec/testCase, so the benchmark results include the throughput.
+// It has the same effect as calling testing.B#SetBytes, and it is ignored during testing.
+func SetBytes(n int64) SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.bytes = &struct{ n int64 }{n: n}
+	})
+}
+
+// ReportUnusedLet will make the Spec report the Let declarations of its subtree,
+// which were not read by any of the tests.
+// This helps to keep spec helper packages and large specifications free from dead variables.
+// Scopes where no test was executed, for example due to the -run flag, are left out from the report.
+func ReportUnusedLet() SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.reportUnused = true
+	})
+}
+
+// Tag is a SpecOption that marks the spec/testCase with the given tags.
+// It has the same effect as calling Spec.Tag in the spec/testCase scope.
+func Tag(tags ...string) SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.tags = append(s.tags, tags...)
+	})
+}
+
+// Skip is a SpecOption that will skip the spec/testCase with the given reason.
+// It is useful to temporarily turn off a test case, while keeping it documented in the specification.
+func Skip(reason string) SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.skip = &struct{ reason string }{reason: reason}
+	})
+}
+
 // Group creates a testing group in the specification.
 // During testCase execution, a group will be bundled together,
 // and parallel tests will run concurrently within the the testing group.
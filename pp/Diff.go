@@ -4,8 +4,12 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"os"
 	"strings"
 	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/adamluzsi/testcase/internal/color"
 )
 
 // Diff format the values in pp.Format and compare the results line by line in a side-by-side style.
@@ -13,104 +17,136 @@ func Diff(v1, v2 any) string {
 	return DiffString(Format(v1), Format(v2))
 }
 
-// DiffString compare strings line by line in a side-by-side style.
-// The diff style is similar to GNU "diff -y".
+// EnvKeyDiffFormat is the environment variable key that selects the output format of DiffString.
+//   - side-by-side (default): similar to GNU "diff -y"
+//   - unified: similar to GNU "diff -u", with "-"/"+" prefixed lines and context lines around the changes
+const EnvKeyDiffFormat = `TESTCASE_DIFF_FORMAT`
+
+const (
+	DiffFormatSideBySide = "side-by-side"
+	DiffFormatUnified    = "unified"
+)
+
+// DiffString compare strings line by line.
+// The lines are matched by their longest common subsequence,
+// and the result is rendered in the format selected by TESTCASE_DIFF_FORMAT.
+// The default diff style is similar to GNU "diff -y".
 func DiffString(val, oth string) string {
+	edits := myersDiff(toLines(val), toLines(oth))
+	if os.Getenv(EnvKeyDiffFormat) == DiffFormatUnified {
+		return toUnified(edits)
+	}
+	return toTable(toSideBySideRows(edits))
+}
+
+// toSideBySideRows pairs up the deleted and inserted lines of a changed block as modified rows,
+// and the rest of the block is shown as only removed or only added lines.
+func toSideBySideRows(edits []diffEdit) []diffTableRow {
 	var (
 		rows     []diffTableRow
-		valPos   int
-		othPos   int
-		valLines = toLines(val)
-		othLines = toLines(oth)
+		deleted  []string
+		inserted []string
 	)
-wrk:
-	for {
-		var (
-			hasVal  bool
-			hasOth  bool
-			valLine string
-			othLine string
-		)
-		if valPos < len(valLines) {
-			hasVal = true
-			valLine = valLines[valPos]
-		}
-		if othPos < len(othLines) {
-			hasOth = true
-			othLine = othLines[othPos]
-		}
-		if !hasVal && !hasOth {
-			break wrk
-		}
-		// only "val" has more lines, "oth" is finished
-		if hasVal && !hasOth {
-			rows = append(rows, diffTableRow{
-				Left:      valLine,
-				Right:     "",
-				Separator: "<",
-			})
-			valPos++
-			continue wrk
-		}
-		// only "oth" has more lines, "val" is finished
-		if !hasVal && hasOth {
-			rows = append(rows, diffTableRow{
-				Left:      "",
-				Right:     othLine,
-				Separator: ">",
-			})
-			othPos++
-			continue wrk
+	flush := func() {
+		for i := 0; i < len(deleted) || i < len(inserted); i++ {
+			switch {
+			case i < len(deleted) && i < len(inserted):
+				rows = append(rows, diffTableRow{Left: deleted[i], Right: inserted[i], Separator: "|"})
+			case i < len(deleted):
+				rows = append(rows, diffTableRow{Left: deleted[i], Right: "", Separator: "<"})
+			default:
+				rows = append(rows, diffTableRow{Left: "", Right: inserted[i], Separator: ">"})
+			}
+		}
+		deleted, inserted = nil, nil
+	}
+	for _, edit := range edits {
+		switch edit.Operation {
+		case diffDelete:
+			deleted = append(deleted, edit.Line)
+		case diffInsert:
+			inserted = append(inserted, edit.Line)
+		default:
+			flush()
+			rows = append(rows, diffTableRow{Left: edit.Line, Right: edit.Line, Separator: ""})
 		}
+	}
+	flush()
+	return rows
+}
 
-		if valLine == othLine {
-			rows = append(rows, diffTableRow{
-				Left:      valLine,
-				Right:     othLine,
-				Separator: "",
-			})
-			valPos++
-			othPos++
-			continue wrk
-		}
-		///////////////////////////////////
-		// not equals, both line present //
-		///////////////////////////////////
+// unifiedContextLines is the number of unchanged lines shown around the changes in the unified format.
+const unifiedContextLines = 3
 
-		// othLine is part of "val",
-		// flush out "val" lines until we reach the current "oth" line there
-		if contains(valLines[valPos:], othLine) {
-			rows = append(rows, diffTableRow{
-				Left:      valLine,
-				Right:     "",
-				Separator: "<",
-			})
-			valPos++
-			continue wrk
+func toUnified(edits []diffEdit) string {
+	buf := &bytes.Buffer{}
+	for start := 0; start < len(edits); {
+		if edits[start].Operation == diffEqual {
+			start++
+			continue
 		}
-
-		// "val"'s line part of other eventually
-		// flush out "oth" lines until we reach the current "val" line
-		if contains(othLines[othPos:], valLine) {
-			rows = append(rows, diffTableRow{
-				Left:      "",
-				Right:     othLine,
-				Separator: ">",
-			})
-			othPos++
-			continue wrk
+		// extend the hunk until the unchanged lines would separate it from the next change
+		end := start
+		for i := start; i < len(edits); i++ {
+			if edits[i].Operation == diffEqual {
+				if 2*unifiedContextLines < i-end {
+					break
+				}
+				continue
+			}
+			end = i + 1
+		}
+		from := start - unifiedContextLines
+		if from < 0 {
+			from = 0
+		}
+		to := end + unifiedContextLines
+		if len(edits) < to {
+			to = len(edits)
 		}
+		writeUnifiedHunk(buf, edits, from, to)
+		start = to
+	}
+	return buf.String()
+}
 
-		rows = append(rows, diffTableRow{
-			Left:      valLine,
-			Right:     othLine,
-			Separator: "|",
-		})
-		valPos++
-		othPos++
-		continue wrk
-	}
-	return toTable(rows)
+func writeUnifiedHunk(buf *bytes.Buffer, edits []diffEdit, from, to int) {
+	var valLine, othLine = 1, 1
+	for _, edit := range edits[:from] {
+		if edit.Operation != diffInsert {
+			valLine++
+		}
+		if edit.Operation != diffDelete {
+			othLine++
+		}
+	}
+	var valCount, othCount int
+	for _, edit := range edits[from:to] {
+		if edit.Operation != diffInsert {
+			valCount++
+		}
+		if edit.Operation != diffDelete {
+			othCount++
+		}
+	}
+	// GNU diff refers to the line before the hunk when the hunk has no line on that side
+	if valCount == 0 {
+		valLine--
+	}
+	if othCount == 0 {
+		othLine--
+	}
+	_, _ = fmt.Fprintf(buf, "%s\n", color.Paint(fmt.Sprintf("@@ -%d,%d +%d,%d @@", valLine, valCount, othLine, othCount), color.Cyan))
+	for _, edit := range edits[from:to] {
+		switch edit.Operation {
+		case diffDelete:
+			_, _ = fmt.Fprintf(buf, "%s\n", color.Paint("-"+edit.Line, color.Red))
+		case diffInsert:
+			_, _ = fmt.Fprintf(buf, "%s\n", color.Paint("+"+edit.Line, color.Green))
+		default:
+			_, _ = fmt.Fprintf(buf, " %s\n", edit.Line)
+		}
+	}
 }
 
 type diffTableRow struct {
@@ -119,15 +155,6 @@ type diffTableRow struct {
 	Separator string
 }
 
-func contains(lines []string, str string) bool {
-	for _, line := range lines {
-		if line == str {
-			return true
-		}
-	}
-	return false
-}
-
 func toTable(rows []diffTableRow) string {
 	var mLen int
 	for _, row := range rows {
@@ -149,9 +176,65 @@ func toTable(rows []diffTableRow) string {
 		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", escape(row.Left), padded(row.Separator), escape(row.Right))
 	}
 	_ = w.Flush()
+	if color.Enabled() {
+		return colorizeTable(buf.String(), rows, escape, padded)
+	}
 	return buf.String()
 }
 
+// colorizeTable colours the cells of the already aligned side-by-side table,
+// since the escape sequences would throw off the column width calculation of the tabwriter.
+func colorizeTable(table string, rows []diffTableRow, escape, padded func(string) string) string {
+	var leftWidth, sepWidth int
+	for _, row := range rows {
+		if n := utf8.RuneCountInString(escape(row.Left)); leftWidth < n {
+			leftWidth = n
+		}
+		if n := utf8.RuneCountInString(padded(row.Separator)); sepWidth < n {
+			sepWidth = n
+		}
+	}
+	var (
+		out   strings.Builder
+		lines = strings.Split(strings.TrimSuffix(table, "\n"), "\n")
+	)
+	for i, line := range lines {
+		if len(rows) <= i || rows[i].Separator == "" {
+			out.WriteString(line + "\n")
+			continue
+		}
+		var (
+			left      = escape(rows[i].Left)
+			leftBytes = len(left) + leftWidth - utf8.RuneCountInString(left)
+			sepBytes  = leftBytes + sepWidth
+		)
+		if len(line) < sepBytes {
+			sepBytes = len(line)
+		}
+		var leftColor, sepColor, rightColor color.Code
+		switch rows[i].Separator {
+		case "<":
+			leftColor, sepColor = color.Red, color.Red
+		case ">":
+			sepColor, rightColor = color.Green, color.Green
+		default:
+			leftColor, sepColor, rightColor = color.Red, color.Yellow, color.Green
+		}
+		out.WriteString(paint(line[:leftBytes], leftColor))
+		out.WriteString(paint(line[leftBytes:sepBytes], sepColor))
+		out.WriteString(paint(line[sepBytes:], rightColor))
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+func paint(text string, code color.Code) string {
+	if code == "" {
+		return text
+	}
+	return color.Paint(text, code)
+}
+
 func toLines(str string) []string {
 	scanner := bufio.NewScanner(strings.NewReader(str))
 	scanner.Split(bufio.ScanLines)
@@ -0,0 +1,203 @@
+package pp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+)
+
+// DiffPaths compares the two values structurally,
+// and reports each difference on its own line with the path of the differing value:
+//
+//	.Orders[3].Items[0].Price: 10 -> 12
+//
+// The values are walked the same way as with Format, and the differing values are formatted with it.
+// Slice elements are compared by their index, and map values by their key.
+// The values of omitted and redacted fields are not revealed, only that they differ.
+// When the values are equal, DiffPaths returns an empty string.
+func DiffPaths(v1, v2 any) string {
+	buf := &bytes.Buffer{}
+	(&pathDiffer{}).Diff(buf, "", reflect.ValueOf(v1), reflect.ValueOf(v2))
+	return buf.String()
+}
+
+const (
+	missingValue       = "<missing>"
+	hiddenFieldDiffers = "<redacted field differs>"
+)
+
+var typeTime = reflect.TypeOf(time.Time{})
+
+type pathDiffer struct {
+	visited map[[2]uintptr]struct{}
+}
+
+func (d *pathDiffer) Diff(w io.Writer, path string, v1, v2 reflect.Value) {
+	defer debugRecover()
+	if !v1.IsValid() || !v2.IsValid() || v1.Type() != v2.Type() {
+		d.report(w, path, v1, v2)
+		return
+	}
+
+	v1, _ = makeAccessable(v1)
+	v2, _ = makeAccessable(v2)
+
+	if _, ok := formatters.Lookup(v1.Type()); ok {
+		d.report(w, path, v1, v2)
+		return
+	}
+
+	switch v1.Kind() {
+	case reflect.Struct:
+		if v1.Type() == typeTime {
+			d.report(w, path, v1, v2)
+			return
+		}
+		for i, n := 0, v1.NumField(); i < n; i++ {
+			field := v1.Type().Field(i)
+			if DefaultFormatter.fieldFormat(field) != fieldFormatDefault {
+				// omitted and redacted values must not leak through the diff, only the fact that they differ
+				d.reportHidden(w, path+"."+field.Name, v1.Field(i), v2.Field(i))
+				continue
+			}
+			d.Diff(w, path+"."+field.Name, v1.Field(i), v2.Field(i))
+		}
+
+	case reflect.Slice, reflect.Array:
+		if v1.Kind() == reflect.Slice && v1.IsNil() != v2.IsNil() {
+			d.reportNil(w, path, v1, v2)
+			return
+		}
+		if v1.Type().ConvertibleTo(typeByteSlice) {
+			d.report(w, path, v1, v2)
+			return
+		}
+		for i, n := 0, maxInt(v1.Len(), v2.Len()); i < n; i++ {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case v2.Len() <= i:
+				d.write(w, elemPath, d.format(v1.Index(i)), missingValue)
+			case v1.Len() <= i:
+				d.write(w, elemPath, missingValue, d.format(v2.Index(i)))
+			default:
+				d.Diff(w, elemPath, v1.Index(i), v2.Index(i))
+			}
+		}
+
+	case reflect.Map:
+		if v1.IsNil() != v2.IsNil() {
+			d.reportNil(w, path, v1, v2)
+			return
+		}
+		keys := v1.MapKeys()
+		for _, key := range v2.MapKeys() {
+			if !v1.MapIndex(key).IsValid() {
+				keys = append(keys, key)
+			}
+		}
+		visitor{}.sortMapKeys(keys)
+		for _, key := range keys {
+			var (
+				keyPath = fmt.Sprintf("%s[%s]", path, d.format(key))
+				val1    = v1.MapIndex(key)
+				val2    = v2.MapIndex(key)
+			)
+			switch {
+			case !val2.IsValid():
+				d.write(w, keyPath, d.format(val1), missingValue)
+			case !val1.IsValid():
+				d.write(w, keyPath, missingValue, d.format(val2))
+			default:
+				d.Diff(w, keyPath, val1, val2)
+			}
+		}
+
+	case reflect.Pointer:
+		if v1.IsNil() || v2.IsNil() {
+			d.report(w, path, v1, v2)
+			return
+		}
+		if !d.visit(v1, v2) {
+			return
+		}
+		d.Diff(w, path, v1.Elem(), v2.Elem())
+
+	case reflect.Interface:
+		if v1.IsNil() || v2.IsNil() {
+			d.report(w, path, v1, v2)
+			return
+		}
+		d.Diff(w, path, v1.Elem(), v2.Elem())
+
+	default:
+		d.report(w, path, v1, v2)
+	}
+}
+
+// visit marks the pointer pair as visited, and reports false if they were already visited,
+// which prevents endless recursion with cyclic data structures.
+func (d *pathDiffer) visit(v1, v2 reflect.Value) bool {
+	if d.visited == nil {
+		d.visited = make(map[[2]uintptr]struct{})
+	}
+	key := [2]uintptr{v1.Pointer(), v2.Pointer()}
+	if _, ok := d.visited[key]; ok {
+		return false
+	}
+	d.visited[key] = struct{}{}
+	return true
+}
+
+func (d *pathDiffer) report(w io.Writer, path string, v1, v2 reflect.Value) {
+	f1, f2 := d.format(v1), d.format(v2)
+	if f1 == f2 {
+		return
+	}
+	d.write(w, path, f1, f2)
+}
+
+// reportHidden reports that an omitted or redacted field differs, without revealing its values.
+func (d *pathDiffer) reportHidden(w io.Writer, path string, v1, v2 reflect.Value) {
+	v1, ok1 := makeAccessable(v1)
+	v2, ok2 := makeAccessable(v2)
+	if !ok1 || !ok2 || reflect.DeepEqual(v1.Interface(), v2.Interface()) {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%s: %s\n", path, hiddenFieldDiffers)
+}
+
+// reportNil reports the difference between a nil and a non-nil slice or map,
+// which would otherwise look the same when they are formatted.
+func (d *pathDiffer) reportNil(w io.Writer, path string, v1, v2 reflect.Value) {
+	f1, f2 := d.format(v1), d.format(v2)
+	if v1.IsNil() {
+		f1 = "nil"
+	}
+	if v2.IsNil() {
+		f2 = "nil"
+	}
+	d.write(w, path, f1, f2)
+}
+
+func (d *pathDiffer) write(w io.Writer, path, f1, f2 string) {
+	if path == "" {
+		_, _ = fmt.Fprintf(w, "%s -> %s\n", f1, f2)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%s: %s -> %s\n", path, f1, f2)
+}
+
+func (d *pathDiffer) format(rv reflect.Value) string {
+	buf := &bytes.Buffer{}
+	(&visitor{Formatter: DefaultFormatter}).Visit(buf, rv, 0)
+	return buf.String()
+}
+
+func maxInt(a, b int) int {
+	if a < b {
+		return b
+	}
+	return a
+}
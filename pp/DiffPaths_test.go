@@ -0,0 +1,94 @@
+package pp_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/pp"
+)
+
+type DiffPathsOrder struct {
+	ID    string
+	Items []DiffPathsItem
+	Meta  map[string]any
+	Note  *string
+}
+
+type DiffPathsItem struct {
+	Name  string
+	Price int
+}
+
+func TestDiffPaths(t *testing.T) {
+	note := "foo"
+	type TestCase struct {
+		V1, V2 any
+		Diff   string
+	}
+	for name, tc := range map[string]TestCase{
+		"when values are equal": {
+			V1:   DiffPathsOrder{ID: "42", Items: []DiffPathsItem{{Name: "foo", Price: 10}}},
+			V2:   DiffPathsOrder{ID: "42", Items: []DiffPathsItem{{Name: "foo", Price: 10}}},
+			Diff: "",
+		},
+		"when scalar values differ": {
+			V1:   1,
+			V2:   2,
+			Diff: "1 -> 2",
+		},
+		"when a nested field differs": {
+			V1: map[string][]DiffPathsOrder{"orders": {
+				{ID: "1"},
+				{ID: "2", Items: []DiffPathsItem{{Name: "foo", Price: 10}}},
+			}},
+			V2: map[string][]DiffPathsOrder{"orders": {
+				{ID: "1"},
+				{ID: "2", Items: []DiffPathsItem{{Name: "foo", Price: 12}}},
+			}},
+			Diff: `["orders"][1].Items[0].Price: 10 -> 12`,
+		},
+		"when slice elements are missing or extra": {
+			V1:   []int{1, 2, 3},
+			V2:   []int{1, 2, 4, 5},
+			Diff: "[2]: 3 -> 4\n[3]: <missing> -> 5",
+		},
+		"when map keys are missing or extra": {
+			V1:   DiffPathsOrder{Meta: map[string]any{"a": 1, "b": 2}},
+			V2:   DiffPathsOrder{Meta: map[string]any{"b": 3, "c": "x"}},
+			Diff: ".Meta[\"a\"]: (interface {})(1) -> <missing>\n.Meta[\"b\"]: 2 -> 3\n.Meta[\"c\"]: <missing> -> (interface {})(\"x\")",
+		},
+		"when pointer is nil on one side": {
+			V1:   DiffPathsOrder{Note: &note},
+			V2:   DiffPathsOrder{},
+			Diff: `.Note: &"foo" -> nil`,
+		},
+		"when pointed values differ": {
+			V1:   &DiffPathsItem{Name: "foo"},
+			V2:   &DiffPathsItem{Name: "bar"},
+			Diff: `.Name: "foo" -> "bar"`,
+		},
+		"when nil and empty slices are compared": {
+			V1:   DiffPathsOrder{Items: nil},
+			V2:   DiffPathsOrder{Items: []DiffPathsItem{}},
+			Diff: `.Items: nil -> []pp_test.DiffPathsItem{}`,
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Diff, strings.TrimSpace(pp.DiffPaths(tc.V1, tc.V2)))
+		})
+	}
+}
+
+func TestDiffPaths_recursion(t *testing.T) {
+	type Node struct {
+		V    int
+		Next *Node
+	}
+	n1 := &Node{V: 1}
+	n1.Next = n1
+	n2 := &Node{V: 2}
+	n2.Next = n2
+	assert.Equal(t, ".V: 1 -> 2", strings.TrimSpace(pp.DiffPaths(n1, n2)))
+}
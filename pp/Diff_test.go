@@ -2,6 +2,8 @@ package pp_test
 
 import (
 	"bufio"
+	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -131,3 +133,123 @@ func TestPrettyPrinter_DiffString_smoke(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffString_insertedLineDoesNotShiftTheRest(t *testing.T) {
+	t.Setenv(pp.EnvKeyDiffFormat, pp.DiffFormatSideBySide)
+	val := "a\nb\nc\nd\ne\nf"
+	oth := "x\na\nb\nc\nd\ne\nf"
+	diff := pp.DiffString(val, oth)
+	assert.Equal(t, 1, strings.Count(diff, ">"))
+	assert.NotContain(t, diff, "|")
+	assert.NotContain(t, diff, "<")
+}
+
+func TestDiffString_unified(t *testing.T) {
+	t.Setenv(pp.EnvKeyDiffFormat, pp.DiffFormatUnified)
+	t.Run("E2E", func(t *testing.T) {
+		const exp = "" +
+			"@@ -1,7 +1,6 @@\n" +
+			" aaa\n" +
+			"-bbb\n" +
+			"+bbbdiff\n" +
+			" ccc\n" +
+			"-ddd\n" +
+			" eee\n" +
+			"+123\n" +
+			" fff\n" +
+			"-ggg\n"
+		assert.Equal(t, exp, pp.DiffString(strings.TrimSpace(DiffStringA), strings.TrimSpace(DiffStringB)))
+	})
+	t.Run("distant changes are shown in separate hunks with context lines", func(t *testing.T) {
+		var val, oth []string
+		for i := 1; i <= 20; i++ {
+			val = append(val, fmt.Sprintf("line %d", i))
+			oth = append(oth, fmt.Sprintf("line %d", i))
+		}
+		oth[1] = "changed 2"
+		oth[17] = "changed 18"
+		const exp = "" +
+			"@@ -1,5 +1,5 @@\n" +
+			" line 1\n" +
+			"-line 2\n" +
+			"+changed 2\n" +
+			" line 3\n" +
+			" line 4\n" +
+			" line 5\n" +
+			"@@ -15,6 +15,6 @@\n" +
+			" line 15\n" +
+			" line 16\n" +
+			" line 17\n" +
+			"-line 18\n" +
+			"+changed 18\n" +
+			" line 19\n" +
+			" line 20\n"
+		assert.Equal(t, exp, pp.DiffString(strings.Join(val, "\n"), strings.Join(oth, "\n")))
+	})
+	t.Run("when only insertion happens at the beginning", func(t *testing.T) {
+		assert.Equal(t, "@@ -0,0 +1,1 @@\n+aaa\n", pp.DiffString("", "aaa"))
+	})
+	t.Run("when values are equal", func(t *testing.T) {
+		assert.Equal(t, "", pp.DiffString("aaa\nbbb", "aaa\nbbb"))
+	})
+}
+
+func TestDiffString_bigInputs(t *testing.T) {
+	t.Setenv(pp.EnvKeyDiffFormat, pp.DiffFormatUnified)
+	lines := func(prefix string, n int) []string {
+		var ls []string
+		for i := 0; i < n; i++ {
+			ls = append(ls, fmt.Sprintf("%s %d", prefix, i))
+		}
+		return ls
+	}
+	t.Run("when a big input has only a few changes, then the changes are still found", func(t *testing.T) {
+		val := lines("line", 100000)
+		oth := append([]string(nil), val...)
+		oth[50000] = "changed"
+		const exp = "" +
+			"@@ -49998,7 +49998,7 @@\n" +
+			" line 49997\n" +
+			" line 49998\n" +
+			" line 49999\n" +
+			"-line 50000\n" +
+			"+changed\n" +
+			" line 50001\n" +
+			" line 50002\n" +
+			" line 50003\n"
+		assert.Equal(t, exp, pp.DiffString(strings.Join(val, "\n"), strings.Join(oth, "\n")))
+	})
+	t.Run("when the inputs are too different, then a plain before/after dump is reported", func(t *testing.T) {
+		val, oth := lines("before", 5000), lines("after", 5000)
+		diff := pp.DiffString(strings.Join(val, "\n"), strings.Join(oth, "\n"))
+		diffLines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+		assert.Equal(t, "@@ -1,5000 +1,5000 @@", diffLines[0])
+		for i, line := range val {
+			assert.Equal(t, "-"+line, diffLines[1+i])
+		}
+		for i, line := range oth {
+			assert.Equal(t, "+"+line, diffLines[1+len(val)+i])
+		}
+	})
+}
+
+var rgxANSI = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestDiffString_color(t *testing.T) {
+	for _, format := range []string{pp.DiffFormatSideBySide, pp.DiffFormatUnified} {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			t.Setenv(pp.EnvKeyDiffFormat, format)
+			val, oth := strings.TrimSpace(DiffStringA), strings.TrimSpace(DiffStringB)
+
+			t.Setenv("TESTCASE_COLOR", "never")
+			plain := pp.DiffString(val, oth)
+			assert.False(t, rgxANSI.MatchString(plain))
+
+			t.Setenv("TESTCASE_COLOR", "always")
+			colored := pp.DiffString(val, oth)
+			assert.True(t, rgxANSI.MatchString(colored))
+			assert.Equal(t, plain, rgxANSI.ReplaceAllString(colored, ""))
+		})
+	}
+}
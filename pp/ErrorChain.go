@@ -0,0 +1,67 @@
+package pp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// ErrorChain formats the wrap chain of the error as a tree,
+// where each level shows the type and the message of the wrapped error:
+//
+//	*fmt.wrapError "create user: write: connection refused"
+//	└─ *fmt.wrapError "write: connection refused"
+//	   └─ *errors.errorString "connection refused"
+//
+// Both single errors, wrapped through Unwrap() error,
+// and multi errors, like the ones made with errors.Join, wrapped through Unwrap() []error, are followed.
+func ErrorChain(err error) string {
+	buf := &bytes.Buffer{}
+	errorChain{}.Visit(buf, err, "", 0)
+	return buf.String()
+}
+
+// errorChainMaxDepth protects against errors which wrap themselves.
+const errorChainMaxDepth = 64
+
+type errorChain struct{}
+
+func (ec errorChain) Visit(w io.Writer, err error, prefix string, depth int) {
+	if err == nil {
+		fmt.Fprint(w, "nil")
+		return
+	}
+	fmt.Fprintf(w, "%s %#v", reflect.TypeOf(err).String(), err.Error())
+	children := ec.unwrap(err)
+	if errorChainMaxDepth <= depth && 0 < len(children) {
+		fmt.Fprintf(w, "\n%s└─ ...", prefix)
+		return
+	}
+	for i, child := range children {
+		branch, indent := "├─ ", "│  "
+		if i == len(children)-1 {
+			branch, indent = "└─ ", "   "
+		}
+		fmt.Fprintf(w, "\n%s%s", prefix, branch)
+		ec.Visit(w, child, prefix+indent, depth+1)
+	}
+}
+
+func (ec errorChain) unwrap(err error) []error {
+	switch err := err.(type) {
+	case interface{ Unwrap() error }:
+		if wrapped := err.Unwrap(); wrapped != nil {
+			return []error{wrapped}
+		}
+	case interface{ Unwrap() []error }:
+		var errs []error
+		for _, wrapped := range err.Unwrap() {
+			if wrapped != nil {
+				errs = append(errs, wrapped)
+			}
+		}
+		return errs
+	}
+	return nil
+}
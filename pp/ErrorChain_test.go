@@ -0,0 +1,64 @@
+package pp_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/pp"
+)
+
+type ErrorChainMulti []error
+
+func (errs ErrorChainMulti) Error() string {
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (errs ErrorChainMulti) Unwrap() []error { return errs }
+
+type ErrorChainSelf struct{}
+
+func (err *ErrorChainSelf) Error() string { return "self" }
+func (err *ErrorChainSelf) Unwrap() error { return err }
+
+func TestErrorChain(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.Equal(t, "nil", pp.ErrorChain(nil))
+	})
+	t.Run("error without wrapping", func(t *testing.T) {
+		assert.Equal(t, `*errors.errorString "boom"`, pp.ErrorChain(errors.New("boom")))
+	})
+	t.Run("wrapped errors", func(t *testing.T) {
+		err := fmt.Errorf("create user: %w", fmt.Errorf("write: %w", errors.New("connection refused")))
+		exp := strings.Join([]string{
+			`*fmt.wrapError "create user: write: connection refused"`,
+			`└─ *fmt.wrapError "write: connection refused"`,
+			`   └─ *errors.errorString "connection refused"`,
+		}, "\n")
+		assert.Equal(t, exp, pp.ErrorChain(err))
+	})
+	t.Run("multi errors", func(t *testing.T) {
+		err := fmt.Errorf("batch: %w", ErrorChainMulti{
+			fmt.Errorf("first: %w", errors.New("foo")),
+			errors.New("second"),
+		})
+		exp := strings.Join([]string{
+			`*fmt.wrapError "batch: first: foo\nsecond"`,
+			`└─ pp_test.ErrorChainMulti "first: foo\nsecond"`,
+			`   ├─ *fmt.wrapError "first: foo"`,
+			`   │  └─ *errors.errorString "foo"`,
+			`   └─ *errors.errorString "second"`,
+		}, "\n")
+		assert.Equal(t, exp, pp.ErrorChain(err))
+	})
+	t.Run("self wrapping error", func(t *testing.T) {
+		out := pp.ErrorChain(&ErrorChainSelf{})
+		assert.Contain(t, out, "└─ ...")
+	})
+}
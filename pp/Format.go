@@ -1,7 +1,6 @@
 package pp
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 	"reflect"
@@ -12,22 +11,18 @@ import (
 	"unicode/utf8"
 )
 
+// Format pretty prints the value with the DefaultFormatter.
 func Format(v any) string {
-	return formatter{}.Format(v)
-}
-
-type formatter struct{}
-
-func (f formatter) Format(v any) string {
-	buf := &bytes.Buffer{}
-	rv := reflect.ValueOf(v)
-	(&visitor{}).Visit(buf, rv, 0)
-	return buf.String()
+	return DefaultFormatter.Format(v)
 }
 
 type visitor struct {
+	Formatter Formatter
+
 	visitedInit sync.Once
 	visited     map[reflect.Value]struct{}
+	// base is the depth where the Formatter's options were applied from.
+	base int
 }
 
 var typeTimeDuration = reflect.TypeOf(time.Duration(0))
@@ -47,6 +42,16 @@ func (v *visitor) Visit(w io.Writer, rv reflect.Value, depth int) {
 
 	rv, _ = makeAccessable(rv)
 
+	if rv.Type() == typeWithOptions && rv.CanInterface() {
+		wo := rv.Interface().(withOptions)
+		(&visitor{Formatter: v.Formatter.with(wo.Options), base: depth}).Visit(w, reflect.ValueOf(wo.Value), depth)
+		return
+	}
+
+	if v.tryRegisteredFormatter(w, rv) {
+		return
+	}
+
 	if rv.Type() == typeTimeDuration {
 		d := time.Duration(rv.Int())
 		fmt.Fprintf(w, "/* %s */ %#v", d.String(), d)
@@ -74,10 +79,16 @@ func (v *visitor) Visit(w io.Writer, rv reflect.Value, depth int) {
 		if v.tryByteSlice(w, rv) {
 			return
 		}
+		if v.tryMaxDepth(w, rv, depth) {
+			return
+		}
 
 		fmt.Fprintf(w, "%s{", v.getTypeName(rv))
 		vLen := rv.Len()
 		for i := 0; i < vLen; i++ {
+			if v.tryMaxElements(w, i, vLen, depth) {
+				break
+			}
 			v.newLine(w, depth+1)
 			v.Visit(w, rv.Index(i), depth+1)
 			fmt.Fprintf(w, ",")
@@ -88,10 +99,16 @@ func (v *visitor) Visit(w io.Writer, rv reflect.Value, depth int) {
 		fmt.Fprint(w, "}")
 
 	case reflect.Map:
+		if v.tryMaxDepth(w, rv, depth) {
+			return
+		}
 		fmt.Fprintf(w, "%s{", v.getTypeName(rv))
 		keys := rv.MapKeys()
 		v.sortMapKeys(keys)
-		for _, key := range keys {
+		for i, key := range keys {
+			if v.tryMaxElements(w, i, len(keys), depth) {
+				break
+			}
 			v.newLine(w, depth+1)
 			v.Visit(w, key, depth+1) // key
 			fmt.Fprintf(w, ": ")
@@ -136,7 +153,7 @@ func (v *visitor) Visit(w io.Writer, rv reflect.Value, depth int) {
 		fmt.Fprintf(w, "make(%s, %d)", rv.Type().String(), rv.Cap())
 
 	case reflect.String:
-		fmt.Fprintf(w, "%#v", rv.String())
+		v.visitString(w, rv.String())
 
 	default:
 		v, ok := makeAccessable(rv)
@@ -216,14 +233,27 @@ func (v visitor) tryStringer(w io.Writer, rv reflect.Value, depth int) bool {
 }
 
 func (v visitor) visitGenericStructure(w io.Writer, rv reflect.Value, depth int) {
+	if v.tryMaxDepth(w, rv, depth) {
+		return
+	}
 	fmt.Fprintf(w, "%s{", rv.Type().String())
-	fieldNum := rv.NumField()
-	for i, fNum := 0, fieldNum; i < fNum; i++ {
-		name := rv.Type().Field(i).Name
+	var fieldNum int
+	for i, fNum := 0, rv.NumField(); i < fNum; i++ {
+		structField := rv.Type().Field(i)
+		name := structField.Name
 		field := rv.FieldByName(name)
+		format := v.Formatter.fieldFormat(structField)
+		if format == fieldFormatOmit || (v.Formatter.OmitZeroFields && field.IsZero()) {
+			continue
+		}
+		fieldNum++
 		v.newLine(w, depth+1)
 		fmt.Fprintf(w, "%s: ", name)
-		v.Visit(w, field, depth+1)
+		if format == fieldFormatRedact {
+			fmt.Fprint(w, "/* redacted */")
+		} else {
+			v.Visit(w, field, depth+1)
+		}
 		fmt.Fprintf(w, ",")
 	}
 	if 0 < fieldNum {
@@ -232,6 +262,46 @@ func (v visitor) visitGenericStructure(w io.Writer, rv reflect.Value, depth int)
 	fmt.Fprint(w, "}")
 }
 
+func (v *visitor) tryRegisteredFormatter(w io.Writer, rv reflect.Value) bool {
+	if !rv.CanInterface() {
+		return false
+	}
+	format, ok := formatters.Lookup(rv.Type())
+	if !ok {
+		return false
+	}
+	format(w, rv)
+	return true
+}
+
+// tryMaxDepth elides the content of the value when it is beyond the Formatter's MaxDepth.
+func (v *visitor) tryMaxDepth(w io.Writer, rv reflect.Value, depth int) bool {
+	if v.Formatter.MaxDepth <= 0 || depth-v.base < v.Formatter.MaxDepth {
+		return false
+	}
+	fmt.Fprintf(w, "%s{/* ... */}", v.getTypeName(rv))
+	return true
+}
+
+// tryMaxElements reports the number of remaining elements when the element at the index is beyond the Formatter's MaxElements.
+func (v *visitor) tryMaxElements(w io.Writer, index, length, depth int) bool {
+	if v.Formatter.MaxElements <= 0 || index < v.Formatter.MaxElements {
+		return false
+	}
+	v.newLine(w, depth+1)
+	fmt.Fprintf(w, "/* and %d more */", length-index)
+	return true
+}
+
+func (v *visitor) visitString(w io.Writer, str string) {
+	if max := v.Formatter.MaxStringLength; 0 < max && max < utf8.RuneCountInString(str) {
+		runes := []rune(str)
+		fmt.Fprintf(w, "%#v /* and %d more characters */", string(runes[:max]), len(runes)-max)
+		return
+	}
+	fmt.Fprintf(w, "%#v", str)
+}
+
 func (v visitor) newLine(w io.Writer, depth int) {
 	_, _ = w.Write([]byte("\n"))
 	v.indent(w, depth)
@@ -0,0 +1,109 @@
+package pp
+
+import (
+	"bytes"
+	"reflect"
+)
+
+// Formatter pretty prints values, and its options allow to limit the size of the output.
+// The zero value formats everything, the same way as Format does by default.
+type Formatter struct {
+	// MaxDepth is the maximum depth of nesting which is formatted.
+	// Structures, slices and maps beyond this depth are elided.
+	MaxDepth int
+	// MaxElements is the maximum number of slice, array and map elements which are formatted.
+	MaxElements int
+	// MaxStringLength is the maximum number of characters formatted from a string.
+	MaxStringLength int
+	// OmitZeroFields makes the struct fields with zero value omitted from the output.
+	OmitZeroFields bool
+	// RedactFields is the list of struct field names that has their value redacted in the output.
+	// Fields can be also redacted with the `pp:"redact"` struct tag, or omitted with the `pp:"-"` struct tag.
+	RedactFields []string
+}
+
+// DefaultFormatter is the Formatter used by Format, PP and the diff functions.
+var DefaultFormatter = Formatter{}
+
+func (f Formatter) Format(v any) string {
+	buf := &bytes.Buffer{}
+	rv := reflect.ValueOf(v)
+	(&visitor{Formatter: f}).Visit(buf, rv, 0)
+	return buf.String()
+}
+
+// FormatOption configures a Formatter.
+type FormatOption func(f *Formatter)
+
+// MaxDepth sets Formatter.MaxDepth.
+func MaxDepth(n int) FormatOption {
+	return func(f *Formatter) { f.MaxDepth = n }
+}
+
+// MaxElements sets Formatter.MaxElements.
+func MaxElements(n int) FormatOption {
+	return func(f *Formatter) { f.MaxElements = n }
+}
+
+// MaxStringLength sets Formatter.MaxStringLength.
+func MaxStringLength(n int) FormatOption {
+	return func(f *Formatter) { f.MaxStringLength = n }
+}
+
+// OmitZeroFields sets Formatter.OmitZeroFields.
+func OmitZeroFields() FormatOption {
+	return func(f *Formatter) { f.OmitZeroFields = true }
+}
+
+// RedactFields adds the field names to Formatter.RedactFields.
+func RedactFields(names ...string) FormatOption {
+	return func(f *Formatter) { f.RedactFields = append(append([]string{}, f.RedactFields...), names...) }
+}
+
+type withOptions struct {
+	Value   any
+	Options []FormatOption
+}
+
+// With allows to format a value with additional options, on top of the options of the used Formatter.
+//
+//	pp.PP(pp.With(db, pp.MaxDepth(1)))
+//	pp.PP(pp.L("users", pp.With(users, pp.MaxElements(3))))
+func With(v any, opts ...FormatOption) withOptions {
+	return withOptions{
+		Value:   v,
+		Options: opts,
+	}
+}
+
+var typeWithOptions = reflect.TypeOf(withOptions{})
+
+func (f Formatter) with(opts []FormatOption) Formatter {
+	for _, opt := range opts {
+		opt(&f)
+	}
+	return f
+}
+
+type fieldFormat int
+
+const (
+	fieldFormatDefault fieldFormat = iota
+	fieldFormatOmit
+	fieldFormatRedact
+)
+
+func (f Formatter) fieldFormat(field reflect.StructField) fieldFormat {
+	switch field.Tag.Get("pp") {
+	case "-":
+		return fieldFormatOmit
+	case "redact":
+		return fieldFormatRedact
+	}
+	for _, name := range f.RedactFields {
+		if name == field.Name {
+			return fieldFormatRedact
+		}
+	}
+	return fieldFormatDefault
+}
@@ -0,0 +1,123 @@
+package pp_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/pp"
+)
+
+type FormatterExampleUser struct {
+	Name     string
+	Password string `pp:"redact"`
+	Internal string `pp:"-"`
+	Token    string
+	Age      int
+	Address  *FormatterExampleAddress
+}
+
+type FormatterExampleAddress struct {
+	City string
+}
+
+func TestFormatter_Format(t *testing.T) {
+	user := FormatterExampleUser{
+		Name:     "John",
+		Password: "secret",
+		Internal: "internal",
+		Token:    "token",
+		Address:  &FormatterExampleAddress{City: "Budapest"},
+	}
+	type TestCase struct {
+		Formatter pp.Formatter
+		Value     any
+		Expected  string
+	}
+	for name, tc := range map[string]TestCase{
+		"zero value formats everything, except the omitted and redacted fields": {
+			Value: user,
+			Expected: "pp_test.FormatterExampleUser{\n" +
+				"\tName: \"John\",\n" +
+				"\tPassword: /* redacted */,\n" +
+				"\tToken: \"token\",\n" +
+				"\tAge: 0,\n" +
+				"\tAddress: &pp_test.FormatterExampleAddress{\n" +
+				"\t\tCity: \"Budapest\",\n" +
+				"\t},\n" +
+				"}",
+		},
+		"MaxDepth elides the nested values": {
+			Formatter: pp.Formatter{MaxDepth: 1},
+			Value:     user,
+			Expected: "pp_test.FormatterExampleUser{\n" +
+				"\tName: \"John\",\n" +
+				"\tPassword: /* redacted */,\n" +
+				"\tToken: \"token\",\n" +
+				"\tAge: 0,\n" +
+				"\tAddress: &pp_test.FormatterExampleAddress{/* ... */},\n" +
+				"}",
+		},
+		"MaxElements truncates slices": {
+			Formatter: pp.Formatter{MaxElements: 2},
+			Value:     []int{1, 2, 3, 4, 5},
+			Expected:  "[]int{\n\t1,\n\t2,\n\t/* and 3 more */\n}",
+		},
+		"MaxElements truncates maps": {
+			Formatter: pp.Formatter{MaxElements: 1},
+			Value:     map[string]int{"a": 1, "b": 2},
+			Expected:  "map[string]int{\n\t\"a\": 1,\n\t/* and 1 more */\n}",
+		},
+		"MaxStringLength truncates strings": {
+			Formatter: pp.Formatter{MaxStringLength: 3},
+			Value:     "foobar",
+			Expected:  `"foo" /* and 3 more characters */`,
+		},
+		"OmitZeroFields skips the zero fields": {
+			Formatter: pp.Formatter{OmitZeroFields: true},
+			Value:     FormatterExampleUser{Name: "John"},
+			Expected:  "pp_test.FormatterExampleUser{\n\tName: \"John\",\n}",
+		},
+		"RedactFields redacts fields by name": {
+			Formatter: pp.Formatter{RedactFields: []string{"Token"}, OmitZeroFields: true},
+			Value:     FormatterExampleUser{Token: "token"},
+			Expected:  "pp_test.FormatterExampleUser{\n\tToken: /* redacted */,\n}",
+		},
+		"With applies options to the wrapped value": {
+			Value:    []any{pp.With([]int{1, 2, 3}, pp.MaxElements(1)), []int{1, 2}},
+			Expected: "[]interface {}{\n\t(interface {})([]int{\n\t\t1,\n\t\t/* and 2 more */\n\t}),\n\t(interface {})([]int{\n\t\t1,\n\t\t2,\n\t}),\n}",
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, tc.Formatter.Format(tc.Value))
+		})
+	}
+}
+
+func TestWith(t *testing.T) {
+	out := pp.Format(pp.With([]string{strings.Repeat("x", 10)}, pp.MaxStringLength(2)))
+	assert.Equal(t, "[]string{\n\t\"xx\" /* and 8 more characters */,\n}", out)
+}
+
+func TestDefaultFormatter(t *testing.T) {
+	og := pp.DefaultFormatter
+	t.Cleanup(func() { pp.DefaultFormatter = og })
+	pp.DefaultFormatter = pp.Formatter{MaxElements: 1}
+	assert.Equal(t, "[]int{\n\t1,\n\t/* and 1 more */\n}", pp.Format([]int{1, 2}))
+}
+
+func TestDiffPaths_redactedFields(t *testing.T) {
+	t.Run("when a redacted field differs, only the fact of the difference is reported", func(t *testing.T) {
+		diff := pp.DiffPaths(FormatterExampleUser{Password: "foo"}, FormatterExampleUser{Password: "bar"})
+		assert.Equal(t, ".Password: <redacted field differs>\n", diff)
+	})
+	t.Run("when an omitted field differs, only the fact of the difference is reported", func(t *testing.T) {
+		diff := pp.DiffPaths(FormatterExampleUser{Internal: "foo"}, FormatterExampleUser{Internal: "bar"})
+		assert.Equal(t, ".Internal: <redacted field differs>\n", diff)
+	})
+	t.Run("when the hidden fields are equal, nothing is reported", func(t *testing.T) {
+		diff := pp.DiffPaths(FormatterExampleUser{Password: "foo", Age: 1}, FormatterExampleUser{Password: "foo", Age: 2})
+		assert.Equal(t, ".Age: 1 -> 2\n", diff)
+	})
+}
@@ -0,0 +1,530 @@
+package pp
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Literal is the Go source code representation of a value.
+type Literal struct {
+	// Expression is a Go expression which evaluates to the value.
+	Expression string
+	// Imports are the import specs the Expression depends on, like `"time"` or `foo2 "example.com/foo"`.
+	Imports []string
+}
+
+func (l Literal) String() string {
+	return l.Expression
+}
+
+// Source returns a Go source file that declares the value as a package level variable.
+func (l Literal) Source(packageName, varName string) string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "package %s\n\n", packageName)
+	if 0 < len(l.Imports) {
+		fmt.Fprintf(buf, "import (\n\t%s\n)\n\n", strings.Join(l.Imports, "\n\t"))
+	}
+	if l.Expression == "nil" || strings.HasPrefix(l.Expression, "nil ") {
+		// an untyped nil needs a type to be declared as a variable
+		fmt.Fprintf(buf, "var %s any = %s\n", varName, l.Expression)
+	} else {
+		fmt.Fprintf(buf, "var %s = %s\n", varName, l.Expression)
+	}
+	if src, err := format.Source(buf.Bytes()); err == nil {
+		return string(src)
+	}
+	return buf.String()
+}
+
+// GoLiteral formats the value as compilable Go source code,
+// which allows to promote a value straight into a test fixture or a golden file.
+//
+// Types are qualified with their package name, and the required imports are listed in Literal.Imports.
+// Unexported struct fields can't be set from another package, thus they are only mentioned in comments.
+// Values of types which can't be referenced from another package,
+// like unexported types or types of a _test or main package,
+// are represented as nil, and their value is only mentioned in a comment.
+// Struct fields with zero value are omitted.
+// Values without a literal form, like functions, are represented as nil with an explanatory comment.
+func GoLiteral(v any) Literal {
+	lw := &literalWriter{
+		imports: make(map[string]string),
+		names:   make(map[string]string),
+		visited: make(map[uintptr]struct{}),
+	}
+	buf := &bytes.Buffer{}
+	lw.Write(buf, reflect.ValueOf(v), typeAny, 0)
+	lit := Literal{Expression: buf.String(), Imports: lw.Imports()}
+	lit.Expression = formatExpression(lit.Expression)
+	return lit
+}
+
+var typeAny = reflect.TypeOf((*any)(nil)).Elem()
+
+// formatExpression formats the expression with gofmt, or returns it as is when it can't be formatted.
+func formatExpression(expr string) string {
+	const prefix = "package p\n\nvar v = "
+	src, err := format.Source([]byte(prefix + expr + "\n"))
+	if err != nil {
+		return expr
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(string(src), prefix), "\n")
+}
+
+type literalWriter struct {
+	imports map[string]string // import path -> package name
+	names   map[string]string // package name -> import path
+	visited map[uintptr]struct{}
+}
+
+func (lw *literalWriter) Imports() []string {
+	var specs []string
+	for path, name := range lw.imports {
+		spec := strconv.Quote(path)
+		if name != defaultPackageName(path) {
+			spec = name + " " + spec
+		}
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specImportPath(specs[i]) < specImportPath(specs[j])
+	})
+	return specs
+}
+
+func specImportPath(spec string) string {
+	return spec[strings.Index(spec, `"`):]
+}
+
+func defaultPackageName(path string) string {
+	if i := strings.LastIndex(path, "/"); 0 <= i {
+		return path[i+1:]
+	}
+	return path
+}
+
+// qualifier returns the name the package is referred by, and records the import.
+// Packages with the same name are aliased.
+func (lw *literalWriter) qualifier(pkgPath, pkgName string) string {
+	if name, ok := lw.imports[pkgPath]; ok {
+		return name
+	}
+	name := pkgName
+	for i := 2; ; i++ {
+		if _, ok := lw.names[name]; !ok {
+			break
+		}
+		name = fmt.Sprintf("%s%d", pkgName, i)
+	}
+	lw.imports[pkgPath] = name
+	lw.names[name] = pkgPath
+	return name
+}
+
+func (lw *literalWriter) typeName(typ reflect.Type) string {
+	if typ == typeByteSlice {
+		return "[]byte"
+	}
+	if typ.Name() != "" {
+		if typ.PkgPath() == "" {
+			return typ.Name() // predeclared types
+		}
+		pkgName := strings.TrimSuffix(typ.String(), "."+typ.Name())
+		return lw.qualifier(typ.PkgPath(), pkgName) + "." + typ.Name()
+	}
+	switch typ.Kind() {
+	case reflect.Pointer:
+		return "*" + lw.typeName(typ.Elem())
+	case reflect.Slice:
+		return "[]" + lw.typeName(typ.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", typ.Len(), lw.typeName(typ.Elem()))
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", lw.typeName(typ.Key()), lw.typeName(typ.Elem()))
+	case reflect.Chan:
+		switch typ.ChanDir() {
+		case reflect.RecvDir:
+			return "<-chan " + lw.typeName(typ.Elem())
+		case reflect.SendDir:
+			return "chan<- " + lw.typeName(typ.Elem())
+		default:
+			return "chan " + lw.typeName(typ.Elem())
+		}
+	case reflect.Interface:
+		if typ.NumMethod() == 0 {
+			return "any"
+		}
+		var methods []string
+		for i := 0; i < typ.NumMethod(); i++ {
+			m := typ.Method(i)
+			methods = append(methods, m.Name+strings.TrimPrefix(lw.typeName(m.Type), "func"))
+		}
+		return "interface{ " + strings.Join(methods, "; ") + " }"
+	case reflect.Func:
+		var in, out []string
+		for i := 0; i < typ.NumIn(); i++ {
+			if typ.IsVariadic() && i == typ.NumIn()-1 {
+				in = append(in, "..."+lw.typeName(typ.In(i).Elem()))
+				continue
+			}
+			in = append(in, lw.typeName(typ.In(i)))
+		}
+		for i := 0; i < typ.NumOut(); i++ {
+			out = append(out, lw.typeName(typ.Out(i)))
+		}
+		name := "func(" + strings.Join(in, ", ") + ")"
+		switch len(out) {
+		case 0:
+		case 1:
+			name += " " + out[0]
+		default:
+			name += " (" + strings.Join(out, ", ") + ")"
+		}
+		return name
+	case reflect.Struct:
+		var fields []string
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			field := lw.typeName(f.Type)
+			if !f.Anonymous {
+				field = f.Name + " " + field
+			}
+			if f.Tag != "" {
+				field += " " + strconv.Quote(string(f.Tag))
+			}
+			fields = append(fields, field)
+		}
+		return "struct{ " + strings.Join(fields, "; ") + " }"
+	default:
+		return typ.String()
+	}
+}
+
+// Write writes the literal of the value.
+// The contextType is the static type where the literal is used,
+// and when it is an interface, the literal is made explicitly typed.
+func (lw *literalWriter) Write(buf *bytes.Buffer, rv reflect.Value, contextType reflect.Type, depth int) {
+	if !rv.IsValid() {
+		buf.WriteString("nil")
+		return
+	}
+	rv, _ = makeAccessable(rv)
+	typed := contextType.Kind() == reflect.Interface
+	if !isReferable(rv.Type()) {
+		fmt.Fprintf(buf, "nil /* %s can't be referenced from another package: %s */", rv.Type().String(), lw.comment(rv))
+		return
+	}
+
+	switch rv.Type() {
+	case typeTime:
+		lw.writeTime(buf, rv)
+		return
+	case typeTimeDuration:
+		fmt.Fprintf(buf, "%s.Duration(%d) /* %s */", lw.qualifier("time", "time"), rv.Int(), time.Duration(rv.Int()))
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		lw.writeConst(buf, rv, strconv.FormatBool(rv.Bool()), typed, reflect.Bool)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		lw.writeConst(buf, rv, strconv.FormatInt(rv.Int(), 10), typed, reflect.Int)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		lw.writeConst(buf, rv, strconv.FormatUint(rv.Uint(), 10), typed, reflect.Invalid)
+	case reflect.Float32, reflect.Float64:
+		lw.writeConst(buf, rv, lw.float(rv.Float(), rv.Type().Bits()), typed, reflect.Float64)
+	case reflect.Complex64, reflect.Complex128:
+		c := rv.Complex()
+		bits := rv.Type().Bits() / 2
+		fmt.Fprintf(buf, "%s(complex(%s, %s))", lw.typeName(rv.Type()), lw.float(real(c), bits), lw.float(imag(c), bits))
+	case reflect.String:
+		lw.writeConst(buf, rv, strconv.Quote(rv.String()), typed, reflect.String)
+	case reflect.Pointer:
+		lw.writePointer(buf, rv, typed, depth)
+	case reflect.Interface:
+		if rv.IsNil() {
+			lw.writeNil(buf, rv, typed)
+			return
+		}
+		lw.Write(buf, rv.Elem(), rv.Type(), depth)
+	case reflect.Struct:
+		lw.writeStruct(buf, rv, depth)
+	case reflect.Slice:
+		if rv.IsNil() {
+			lw.writeNil(buf, rv, typed)
+			return
+		}
+		if lw.tryByteSlice(buf, rv) {
+			return
+		}
+		lw.writeElements(buf, rv, depth)
+	case reflect.Array:
+		lw.writeElements(buf, rv, depth)
+	case reflect.Map:
+		if rv.IsNil() {
+			lw.writeNil(buf, rv, typed)
+			return
+		}
+		lw.writeMap(buf, rv, depth)
+	case reflect.Chan:
+		if rv.IsNil() {
+			lw.writeNil(buf, rv, typed)
+			return
+		}
+		fmt.Fprintf(buf, "make(%s, %d)", lw.typeName(rv.Type()), rv.Cap())
+	case reflect.Func:
+		if rv.IsNil() {
+			lw.writeNil(buf, rv, typed)
+			return
+		}
+		fmt.Fprintf(buf, "(%s)(nil) /* func value can't be represented as a literal */", lw.typeName(rv.Type()))
+	default:
+		fmt.Fprintf(buf, "nil /* %s value can't be represented as a literal */", rv.Type().String())
+	}
+}
+
+// writeConst writes a constant literal.
+// Untyped constants take the type of their context,
+// but within an interface, they need an explicit conversion, unless their default type is the value's type.
+// Function calls like math.NaN() are not untyped constants, so they always need a conversion, except to their own type.
+func (lw *literalWriter) writeConst(buf *bytes.Buffer, rv reflect.Value, lit string, typed bool, defaultKind reflect.Kind) {
+	var (
+		typ       = rv.Type()
+		isDefault = typ.Name() == typ.Kind().String() && typ.PkgPath() == "" && typ.Kind() == defaultKind
+		isCall    = strings.HasSuffix(lit, ")")
+	)
+	if isDefault {
+		if typed && defaultKind == reflect.Float64 && !strings.ContainsAny(lit, ".eE(") {
+			lit += ".0" // otherwise the untyped constant would default to int
+		}
+		buf.WriteString(lit)
+		return
+	}
+	if !typed && !isCall {
+		buf.WriteString(lit)
+		return
+	}
+	fmt.Fprintf(buf, "%s(%s)", lw.typeName(typ), lit)
+}
+
+func (lw *literalWriter) float(f float64, bits int) string {
+	switch {
+	case math.IsNaN(f):
+		return lw.qualifier("math", "math") + ".NaN()"
+	case math.IsInf(f, 1):
+		return lw.qualifier("math", "math") + ".Inf(1)"
+	case math.IsInf(f, -1):
+		return lw.qualifier("math", "math") + ".Inf(-1)"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, bits)
+	}
+}
+
+func (lw *literalWriter) writeNil(buf *bytes.Buffer, rv reflect.Value, typed bool) {
+	if typed && rv.Kind() != reflect.Interface {
+		fmt.Fprintf(buf, "(%s)(nil)", lw.typeName(rv.Type()))
+		return
+	}
+	buf.WriteString("nil")
+}
+
+func (lw *literalWriter) writeTime(buf *bytes.Buffer, rv reflect.Value) {
+	pkg := lw.qualifier("time", "time")
+	if !rv.CanInterface() {
+		fmt.Fprintf(buf, "%s.Time{} /* inaccessible */", pkg)
+		return
+	}
+	t := rv.Interface().(time.Time)
+	var loc string
+	switch t.Location() {
+	case time.UTC:
+		loc = pkg + ".UTC"
+	case time.Local:
+		loc = pkg + ".Local"
+	default:
+		name, offset := t.Zone()
+		loc = fmt.Sprintf("%s.FixedZone(%q, %d)", pkg, name, offset)
+	}
+	fmt.Fprintf(buf, "%s.Date(%d, %s.%s, %d, %d, %d, %d, %d, %s)",
+		pkg, t.Year(), pkg, t.Month().String(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+func (lw *literalWriter) writePointer(buf *bytes.Buffer, rv reflect.Value, typed bool, depth int) {
+	if rv.IsNil() {
+		lw.writeNil(buf, rv, typed)
+		return
+	}
+	if _, ok := lw.visited[rv.Pointer()]; ok {
+		fmt.Fprintf(buf, "(%s)(nil) /* cyclic reference */", lw.typeName(rv.Type()))
+		return
+	}
+	lw.visited[rv.Pointer()] = struct{}{}
+	defer delete(lw.visited, rv.Pointer())
+
+	elem := rv.Elem()
+	switch elem.Kind() {
+	case reflect.Struct, reflect.Array, reflect.Slice, reflect.Map:
+		if elem.Type() != typeTime {
+			buf.WriteString("&")
+			lw.Write(buf, elem, elem.Type(), depth)
+			return
+		}
+	}
+	// only composite literals are addressable, other values need a variable
+	typeName := lw.typeName(elem.Type())
+	fmt.Fprintf(buf, "func() *%s { v := (%s)(", typeName, typeName)
+	lw.Write(buf, elem, elem.Type(), depth)
+	buf.WriteString("); return &v }()")
+}
+
+func (lw *literalWriter) writeStruct(buf *bytes.Buffer, rv reflect.Value, depth int) {
+	typ := rv.Type()
+	fmt.Fprintf(buf, "%s{", lw.typeName(typ))
+	var written int
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		value := rv.Field(i)
+		if value.IsZero() {
+			continue
+		}
+		written++
+		lw.newLine(buf, depth+1)
+		if !field.IsExported() {
+			fmt.Fprintf(buf, "/* unexported %s: %s */", field.Name, lw.comment(value))
+			continue
+		}
+		if field.Type.Kind() != reflect.Interface && !isReferable(field.Type) {
+			fmt.Fprintf(buf, "/* %s: %s, %s can't be referenced from another package */", field.Name, lw.comment(value), field.Type.String())
+			continue
+		}
+		fmt.Fprintf(buf, "%s: ", field.Name)
+		lw.Write(buf, value, field.Type, depth+1)
+		buf.WriteString(",")
+	}
+	if 0 < written {
+		lw.newLine(buf, depth)
+	}
+	buf.WriteString("}")
+}
+
+func (lw *literalWriter) writeElements(buf *bytes.Buffer, rv reflect.Value, depth int) {
+	fmt.Fprintf(buf, "%s{", lw.typeName(rv.Type()))
+	elemType := rv.Type().Elem()
+	for i := 0; i < rv.Len(); i++ {
+		lw.newLine(buf, depth+1)
+		lw.Write(buf, rv.Index(i), elemType, depth+1)
+		buf.WriteString(",")
+	}
+	if 0 < rv.Len() {
+		lw.newLine(buf, depth)
+	}
+	buf.WriteString("}")
+}
+
+func (lw *literalWriter) writeMap(buf *bytes.Buffer, rv reflect.Value, depth int) {
+	fmt.Fprintf(buf, "%s{", lw.typeName(rv.Type()))
+	keys := rv.MapKeys()
+	visitor{}.sortMapKeys(keys)
+	for _, key := range keys {
+		lw.newLine(buf, depth+1)
+		lw.Write(buf, key, rv.Type().Key(), depth+1)
+		buf.WriteString(": ")
+		lw.Write(buf, rv.MapIndex(key), rv.Type().Elem(), depth+1)
+		buf.WriteString(",")
+	}
+	if 0 < len(keys) {
+		lw.newLine(buf, depth)
+	}
+	buf.WriteString("}")
+}
+
+func (lw *literalWriter) tryByteSlice(buf *bytes.Buffer, rv reflect.Value) bool {
+	if rv.Type().Elem().Kind() != reflect.Uint8 || !rv.Type().ConvertibleTo(typeByteSlice) {
+		return false
+	}
+	data := rv.Convert(typeByteSlice).Bytes()
+	if !utf8.Valid(data) {
+		return false
+	}
+	fmt.Fprintf(buf, "%s(%s)", lw.typeName(rv.Type()), strconv.Quote(string(data)))
+	return true
+}
+
+// isReferable reports whether the type can be referred to from another package.
+// Unexported types, and the types of _test and main packages can't be, neither the types composed from them.
+// Instantiated generic types are only referable when their type arguments don't belong to a package,
+// as reflect names the type arguments with their full import path, which can't be qualified.
+func isReferable(typ reflect.Type) bool {
+	if typ.Name() != "" {
+		if typ.PkgPath() == "" {
+			return true // predeclared types
+		}
+		if i := strings.Index(typ.Name(), "["); 0 <= i && strings.Contains(typ.Name()[i:], ".") {
+			return false
+		}
+		return token.IsExported(typ.Name()) && isImportable(typ.PkgPath())
+	}
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Chan:
+		return isReferable(typ.Elem())
+	case reflect.Map:
+		return isReferable(typ.Key()) && isReferable(typ.Elem())
+	case reflect.Func:
+		for i := 0; i < typ.NumIn(); i++ {
+			if !isReferable(typ.In(i)) {
+				return false
+			}
+		}
+		for i := 0; i < typ.NumOut(); i++ {
+			if !isReferable(typ.Out(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Interface:
+		for i := 0; i < typ.NumMethod(); i++ {
+			if m := typ.Method(i); !m.IsExported() || !isReferable(m.Type) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if f := typ.Field(i); !f.IsExported() || !isReferable(f.Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+func isImportable(pkgPath string) bool {
+	return pkgPath != "main" && !strings.HasSuffix(pkgPath, "_test")
+}
+
+// comment formats the value in a single line, which can be safely placed into a block comment.
+func (lw *literalWriter) comment(rv reflect.Value) string {
+	out := &bytes.Buffer{}
+	(&visitor{Formatter: DefaultFormatter}).Visit(out, rv, 0)
+	lines := strings.Split(out.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.ReplaceAll(strings.Join(lines, " "), "*/", "* /")
+}
+
+func (lw *literalWriter) newLine(buf *bytes.Buffer, depth int) {
+	buf.WriteString("\n")
+	buf.WriteString(strings.Repeat("\t", depth))
+}
@@ -0,0 +1,214 @@
+package pp_test
+
+import (
+	"errors"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"math"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/example/mycontainer"
+	"github.com/adamluzsi/testcase/pp"
+)
+
+type GoLiteralRaw []byte
+
+// GoLiteralStruct is an unnamed struct type, which can be referenced from another package,
+// unlike the types declared in this _test package.
+type GoLiteralStruct = struct {
+	Name    string
+	Score   float32
+	Timeout time.Duration
+	Nested  *url.URL
+	Any     any
+	Raw     []byte
+}
+
+type GoLiteralExample struct {
+	Name     string
+	Age      int
+	Score    float32
+	Tags     []string
+	Labels   map[string]int
+	Created  time.Time
+	Timeout  time.Duration
+	Nested   *GoLiteralExample
+	Any      any
+	Nickname *string
+	Raw      GoLiteralRaw
+	secret   string
+}
+
+func TestGoLiteral(t *testing.T) {
+	nickname := "Johnny"
+	type TestCase struct {
+		Value      any
+		Expression string
+		Imports    []string
+	}
+	for name, tc := range map[string]TestCase{
+		"int":                             {Value: 42, Expression: "42"},
+		"named int within interface":      {Value: time.Month(3), Expression: "time.Month(3)", Imports: []string{`"time"`}},
+		"int32 within interface":          {Value: int32(42), Expression: "int32(42)"},
+		"integral float within interface": {Value: 42.0, Expression: "42.0"},
+		"NaN":                             {Value: math.NaN(), Expression: "math.NaN()", Imports: []string{`"math"`}},
+		"string":                          {Value: "foo\n", Expression: `"foo\n"`},
+		"nil":                             {Value: nil, Expression: "nil"},
+		"nil slice within interface":      {Value: []int(nil), Expression: "([]int)(nil)"},
+		"slice of any": {
+			Value:      []any{1, "2", int64(3), nil},
+			Expression: "[]any{\n\t1,\n\t\"2\",\n\tint64(3),\n\tnil,\n}",
+		},
+		"time": {
+			Value:      time.Date(2022, time.March, 4, 5, 6, 7, 8, time.UTC),
+			Expression: "time.Date(2022, time.March, 4, 5, 6, 7, 8, time.UTC)",
+			Imports:    []string{`"time"`},
+		},
+		"pointer to a string": {
+			Value:      &nickname,
+			Expression: `func() *string { v := (string)("Johnny"); return &v }()`,
+		},
+		"struct": {
+			Value: GoLiteralStruct{
+				Name:    "John",
+				Score:   1.5,
+				Timeout: time.Second,
+				Nested:  &url.URL{Scheme: "https", User: url.User("john")},
+				Any:     uint8(1),
+				Raw:     []byte(`{}`),
+			},
+			Expression: "struct {\n" +
+				"\tName    string\n" +
+				"\tScore   float32\n" +
+				"\tTimeout time.Duration\n" +
+				"\tNested  *url.URL\n" +
+				"\tAny     any\n" +
+				"\tRaw     []byte\n" +
+				"}{\n" +
+				"\tName:    \"John\",\n" +
+				"\tScore:   1.5,\n" +
+				"\tTimeout: time.Duration(1000000000), /* 1s */\n" +
+				"\tNested: &url.URL{\n" +
+				"\t\tScheme: \"https\",\n" +
+				"\t\tUser:   &url.Userinfo{\n" +
+				"\t\t\t/* unexported username: \"john\" */\n" +
+				"\t\t},\n" +
+				"\t},\n" +
+				"\tAny: uint8(1),\n" +
+				"\tRaw: []byte(\"{}\"),\n" +
+				"}",
+			Imports: []string{`"net/url"`, `"time"`},
+		},
+		"error within a struct": {
+			Value: struct{ Err error }{Err: errors.New("boom")},
+			Expression: "struct{ Err error }{\n" +
+				"\tErr: nil, /* *errors.errorString can't be referenced from another package: &errors.errorString{ s: \"boom\", } */\n" +
+				"}",
+		},
+		"type of a _test package": {
+			Value:      GoLiteralRaw("{}"),
+			Expression: `nil /* pp_test.GoLiteralRaw can't be referenced from another package: pp_test.GoLiteralRaw("{}") */`,
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			lit := pp.GoLiteral(tc.Value)
+			t.Logf("\n%s", lit.Source("fixtures", "Value"))
+			typeCheckGoLiteral(t, lit)
+			assert.Equal(t, tc.Expression, lit.Expression)
+			assert.Equal(t, tc.Imports, lit.Imports)
+		})
+	}
+}
+
+// typeCheckGoLiteral asserts that the source of the literal compiles as a package of its own.
+func typeCheckGoLiteral(tb testing.TB, lit pp.Literal) {
+	tb.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "fixtures.go", lit.Source("fixtures", "Value"), parser.AllErrors)
+	assert.NoError(tb, err)
+	conf := types.Config{Importer: &moduleImporter{fset: fset, std: importer.ForCompiler(fset, "source", nil)}}
+	_, err = conf.Check("fixtures", fset, []*ast.File{file}, nil)
+	assert.NoError(tb, err)
+}
+
+const modulePath = "github.com/adamluzsi/testcase"
+
+// moduleImporter imports the packages of this module from their source,
+// and delegates the rest to the standard importer.
+type moduleImporter struct {
+	fset *token.FileSet
+	std  types.Importer
+	pkgs map[string]*types.Package
+}
+
+func (i *moduleImporter) Import(path string) (*types.Package, error) {
+	if !strings.HasPrefix(path, modulePath+"/") {
+		return i.std.Import(path)
+	}
+	if pkg, ok := i.pkgs[path]; ok {
+		return pkg, nil
+	}
+	dir := filepath.Join("..", filepath.FromSlash(strings.TrimPrefix(path, modulePath+"/")))
+	pkgs, err := parser.ParseDir(i.fset, dir, func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, p := range pkgs {
+		for _, file := range p.Files {
+			files = append(files, file)
+		}
+	}
+	pkg, err := (&types.Config{Importer: i}).Check(path, i.fset, files, nil)
+	if err != nil {
+		return nil, err
+	}
+	if i.pkgs == nil {
+		i.pkgs = make(map[string]*types.Package)
+	}
+	i.pkgs[path] = pkg
+	return pkg, nil
+}
+
+func TestGoLiteral_sourceCompiles(t *testing.T) {
+	nickname := "Johnny"
+	value := map[string]any{
+		"user": &GoLiteralStruct{
+			Name: "John",
+			Any:  []float32{1, float32(math.Inf(1))},
+		},
+		"tags":     []string{"a", "b"},
+		"labels":   map[string]int{"x": 1},
+		"created":  time.Date(2022, time.March, 4, 5, 6, 7, 8, time.FixedZone("CET", 3600)),
+		"nickname": &nickname,
+		"example":  GoLiteralExample{Name: "John"},
+		"chan":     make(chan int, 3),
+		"func":     func() {},
+		"bytes":    []byte{0xff, 0x00},
+		"box":      mycontainer.Box[int]{Value: 42},
+		"item":     mycontainer.Box[mycontainer.Item]{Value: mycontainer.Item{Name: "John"}},
+	}
+	lit := pp.GoLiteral(value)
+	src := lit.Source("fixtures", "Value")
+	t.Logf("\n%s", src)
+	typeCheckGoLiteral(t, lit)
+	assert.Contain(t, src, `time.FixedZone("CET", 3600)`)
+	assert.Contain(t, src, `float32(math.Inf(1))`)
+	assert.Contain(t, src, `/* pp_test.GoLiteralExample can't be referenced from another package`)
+	assert.NotContain(t, lit.Imports, `"github.com/adamluzsi/testcase/pp_test"`)
+	assert.Contain(t, src, "mycontainer.Box[int]{\n\t\tValue: 42,\n\t}")
+	assert.Contain(t, src, `/* mycontainer.Box[github.com/adamluzsi/testcase/internal/example/mycontainer.Item] can't be referenced from another package`)
+	assert.Contain(t, lit.Imports, `"github.com/adamluzsi/testcase/internal/example/mycontainer"`)
+}
@@ -0,0 +1,79 @@
+package pp
+
+import (
+	"io"
+	"reflect"
+	"sync"
+)
+
+// RegisterFormatter registers a formatting function for the values of type T,
+// which is used by Format, PP and the diffs, instead of the default formatting of T.
+// This allows domain types, like money, decimals or UUIDs, to be rendered meaningfully in assertion failures.
+//
+// RegisterFormatter returns a function that unregisters the formatter,
+// and restores the previously registered formatter of T, if there was any.
+// To scope the registration to a test, pass the unregister function to tb.Cleanup:
+//
+//	t.Cleanup(pp.RegisterFormatter(func(w io.Writer, v Money) {
+//		fmt.Fprintf(w, "%d.%02d %s", v.Amount/100, v.Amount%100, v.Currency)
+//	}))
+//
+// The registry is global, so tests registering formatters should not run in parallel
+// with tests that depend on the default formatting of the same type.
+func RegisterFormatter[T any](fn func(w io.Writer, v T)) (unregister func()) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	return formatters.Register(typ, func(w io.Writer, rv reflect.Value) {
+		fn(w, rv.Interface().(T))
+	})
+}
+
+var formatters = &formatterRegistry{}
+
+type formatterRegistry struct {
+	mutex   sync.RWMutex
+	entries map[reflect.Type][]*formatterEntry
+}
+
+type formatterEntry struct {
+	Format func(w io.Writer, rv reflect.Value)
+}
+
+func (r *formatterRegistry) Register(typ reflect.Type, fn func(w io.Writer, rv reflect.Value)) func() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.entries == nil {
+		r.entries = make(map[reflect.Type][]*formatterEntry)
+	}
+	entry := &formatterEntry{Format: fn}
+	r.entries[typ] = append(r.entries[typ], entry)
+	var once sync.Once
+	return func() { once.Do(func() { r.unregister(typ, entry) }) }
+}
+
+func (r *formatterRegistry) unregister(typ reflect.Type, entry *formatterEntry) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	entries := r.entries[typ]
+	for i, e := range entries {
+		if e == entry {
+			entries = append(entries[:i:i], entries[i+1:]...)
+			break
+		}
+	}
+	if len(entries) == 0 {
+		delete(r.entries, typ)
+		return
+	}
+	r.entries[typ] = entries
+}
+
+// Lookup returns the most recently registered formatter of the type.
+func (r *formatterRegistry) Lookup(typ reflect.Type) (func(w io.Writer, rv reflect.Value), bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	entries := r.entries[typ]
+	if len(entries) == 0 {
+		return nil, false
+	}
+	return entries[len(entries)-1].Format, true
+}
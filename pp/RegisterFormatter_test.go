@@ -0,0 +1,61 @@
+package pp_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/doubles"
+	"github.com/adamluzsi/testcase/pp"
+)
+
+type RegisterFormatterMoney struct {
+	Amount   int64
+	Currency string
+}
+
+type RegisterFormatterOrder struct {
+	ID    string
+	Total RegisterFormatterMoney
+}
+
+func TestRegisterFormatter(t *testing.T) {
+	money := RegisterFormatterMoney{Amount: 1050, Currency: "EUR"}
+	defaultFormat := pp.Format(money)
+
+	t.Run("registered formatter is used for the type, even when nested", func(t *testing.T) {
+		t.Cleanup(pp.RegisterFormatter(func(w io.Writer, v RegisterFormatterMoney) {
+			_, _ = fmt.Fprintf(w, "%d.%02d %s", v.Amount/100, v.Amount%100, v.Currency)
+		}))
+		assert.Equal(t, "10.50 EUR", pp.Format(money))
+		assert.Equal(t, "&10.50 EUR", pp.Format(&money))
+		assert.Equal(t, "pp_test.RegisterFormatterOrder{\n\tID: \"42\",\n\tTotal: 10.50 EUR,\n}",
+			pp.Format(RegisterFormatterOrder{ID: "42", Total: money}))
+		assert.Equal(t, ".Total: 10.50 EUR -> 12.00 EUR\n", pp.DiffPaths(
+			RegisterFormatterOrder{Total: money},
+			RegisterFormatterOrder{Total: RegisterFormatterMoney{Amount: 1200, Currency: "EUR"}}))
+	})
+
+	t.Run("after the cleanup, the default formatting is restored", func(t *testing.T) {
+		assert.Equal(t, defaultFormat, pp.Format(money))
+	})
+
+	t.Run("registrations can be nested, and unregistering restores the previous formatter", func(t *testing.T) {
+		outer := pp.RegisterFormatter(func(w io.Writer, v RegisterFormatterMoney) { _, _ = fmt.Fprint(w, "outer") })
+		defer outer()
+		inner := pp.RegisterFormatter(func(w io.Writer, v RegisterFormatterMoney) { _, _ = fmt.Fprint(w, "inner") })
+		assert.Equal(t, "inner", pp.Format(money))
+		inner()
+		inner()
+		assert.Equal(t, "outer", pp.Format(money))
+	})
+
+	t.Run("registration scoped to a test is removed when the test finishes", func(t *testing.T) {
+		dtb := &doubles.TB{}
+		dtb.Cleanup(pp.RegisterFormatter(func(w io.Writer, v RegisterFormatterMoney) { _, _ = fmt.Fprint(w, "money") }))
+		assert.Equal(t, "money", pp.Format(money))
+		dtb.Finish()
+		assert.Equal(t, defaultFormat, pp.Format(money))
+	})
+}
@@ -0,0 +1,148 @@
+package pp
+
+type diffOperation int
+
+const (
+	diffEqual diffOperation = iota
+	diffDelete
+	diffInsert
+)
+
+type diffEdit struct {
+	Operation diffOperation
+	Line      string
+}
+
+const (
+	// myersMaxLines is the number of differing lines above which the diff is not searched,
+	// and a plain before/after dump is reported instead.
+	myersMaxLines = 50000
+	// myersMaxEditDistance is the number of edits above which the search for the shortest edit script is given up,
+	// and a plain before/after dump is reported instead.
+	myersMaxEditDistance = 1000
+)
+
+// myersDiff computes the shortest edit script which turns the "val" lines into the "oth" lines,
+// using Eugene W. Myers' O(ND) difference algorithm.
+// The equal lines of the result form the longest common subsequence of the two line lists.
+//
+// The common prefix and suffix of the line lists are matched without the search.
+// When the rest is too long, or too different, the result is a plain before/after dump,
+// which deletes every remaining "val" line and inserts every remaining "oth" line.
+func myersDiff(val, oth []string) []diffEdit {
+	var prefix, suffix int
+	for prefix < len(val) && prefix < len(oth) && val[prefix] == oth[prefix] {
+		prefix++
+	}
+	for suffix < len(val)-prefix && suffix < len(oth)-prefix &&
+		val[len(val)-1-suffix] == oth[len(oth)-1-suffix] {
+		suffix++
+	}
+	var edits []diffEdit
+	for _, line := range val[:prefix] {
+		edits = append(edits, diffEdit{Operation: diffEqual, Line: line})
+	}
+	edits = append(edits, myersSearch(val[prefix:len(val)-suffix], oth[prefix:len(oth)-suffix])...)
+	for _, line := range val[len(val)-suffix:] {
+		edits = append(edits, diffEdit{Operation: diffEqual, Line: line})
+	}
+	return edits
+}
+
+func myersSearch(val, oth []string) []diffEdit {
+	var (
+		n      = len(val)
+		m      = len(oth)
+		max    = n + m
+		offset = max + 1
+		v      = make([]int, 2*max+3)
+		trace  [][]int
+	)
+	if max == 0 {
+		return nil
+	}
+	if myersMaxLines < max {
+		return beforeAfterEdits(val, oth)
+	}
+search:
+	for d := 0; d <= max; d++ {
+		if myersMaxEditDistance < d {
+			return beforeAfterEdits(val, oth)
+		}
+		// only the [-d, d] diagonals of the frontier are read when the edits are collected back
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // move down: insertion
+			} else {
+				x = v[offset+k-1] + 1 // move right: deletion
+			}
+			y := x - k
+			for x < n && y < m && val[x] == oth[y] {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+			if n <= x && m <= y {
+				break search
+			}
+		}
+	}
+	return myersBacktrack(val, oth, trace)
+}
+
+// myersBacktrack walks back the recorded search frontiers from the end of both line lists,
+// and collects the edits in reverse order.
+// The frontier of the step d holds the diagonals from -d to d.
+func myersBacktrack(val, oth []string, trace [][]int) []diffEdit {
+	var (
+		edits []diffEdit
+		x     = len(val)
+		y     = len(oth)
+	)
+	frontier := func(d, k int) int {
+		if k < -d || d < k {
+			return 0
+		}
+		return trace[d][d+k]
+	}
+	for d := len(trace) - 1; 0 <= d; d-- {
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && frontier(d, k-1) < frontier(d, k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := frontier(d, prevK)
+		prevY := prevX - prevK
+		for prevX < x && prevY < y {
+			edits = append(edits, diffEdit{Operation: diffEqual, Line: val[x-1]})
+			x, y = x-1, y-1
+		}
+		if 0 < d {
+			if x == prevX {
+				edits = append(edits, diffEdit{Operation: diffInsert, Line: oth[y-1]})
+			} else {
+				edits = append(edits, diffEdit{Operation: diffDelete, Line: val[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// beforeAfterEdits is the edit script which deletes every "val" line, and then inserts every "oth" line.
+func beforeAfterEdits(val, oth []string) []diffEdit {
+	edits := make([]diffEdit, 0, len(val)+len(oth))
+	for _, line := range val {
+		edits = append(edits, diffEdit{Operation: diffDelete, Line: line})
+	}
+	for _, line := range oth {
+		edits = append(edits, diffEdit{Operation: diffInsert, Line: line})
+	}
+	return edits
+}
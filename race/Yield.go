@@ -0,0 +1,15 @@
+// Package race provides yield points for the deterministic interleaving exploration
+// of testcase.RaceN and testcase.RaceExplore.
+//
+// Sprinkle race.Yield calls into the concurrent code, at the points where other goroutines could interleave,
+// like between reading and writing a shared state.
+// Outside a RaceN or RaceExplore participant, race.Yield is a cheap no-op,
+// thus it is safe to keep in the production code, similarly to the clock package.
+package race
+
+import "github.com/adamluzsi/testcase/internal/interleave"
+
+// Yield marks a point in the code, where the execution can be handed over to another race participant.
+// During RaceN and RaceExplore, the participant that reaches the yield point
+// is paused, and the next participant to continue is picked based on the interleaving seed.
+func Yield() { interleave.Yield() }
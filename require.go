@@ -0,0 +1,119 @@
+package testcase
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/adamluzsi/testcase/internal"
+	"github.com/adamluzsi/testcase/internal/doubles"
+)
+
+// EnvKeyRequire is the environment variable key that will be checked to determine
+// what should happen with the tests when a Spec.Require precondition is not met.
+//
+// Mods:
+// - skip: skip the tests of the Spec subtree (default)
+// - fail: fail the tests of the Spec subtree
+const EnvKeyRequire = `TESTCASE_REQUIRE`
+
+const (
+	requireModSkip = `skip`
+	requireModFail = `fail`
+)
+
+const requirementNotMetFormat = `requirement %q is not met: %s`
+
+type requirement struct {
+	Name  string
+	Check func(tb testing.TB) error
+
+	once sync.Once
+	err  error
+}
+
+// Require defines a precondition for the tests of the current Spec and its sub-specs.
+// The precondition check is evaluated only once, when the first test of the subtree is about to run,
+// and its result is shared across all the tests of the subtree.
+//
+// When the precondition is not met, every test in the subtree is skipped with a single clear reason,
+// instead of each test failing separately, for example, with connection errors.
+// To fail the tests instead, set the TESTCASE_REQUIRE environment variable to "fail".
+// This is useful in a CI pipeline, where a missing dependency should not go unnoticed.
+//
+// The testing.TB received by the check belongs to the Spec where Require was declared.
+// Cleanups registered during the check are executed after the Spec subtree is finished.
+func (spec *Spec) Require(name string, check func(tb testing.TB) error) {
+	spec.testingTB.Helper()
+	if spec.immutable {
+		spec.testingTB.Fatalf(warnEventOnImmutableFormat, `Require`)
+	}
+	spec.requirements = append(spec.requirements, &requirement{
+		Name:  name,
+		Check: check,
+	})
+}
+
+func (spec *Spec) checkRequirements(tb testing.TB) {
+	spec.testingTB.Helper()
+	tb.Helper()
+	for _, s := range spec.specsFromParent() {
+		for _, req := range s.requirements {
+			err := req.Verify(tb, s.testingTB)
+			if err == nil {
+				continue
+			}
+			msg := fmt.Sprintf(requirementNotMetFormat, req.Name, err.Error())
+			if getRequireMod() == requireModFail {
+				tb.Fatal(msg)
+			}
+			tb.Skip(msg)
+		}
+	}
+}
+
+// Verify checks the requirement once, and reports the result to the testing.TB which triggered the check.
+func (req *requirement) Verify(tb, owner testing.TB) error {
+	tb.Helper()
+	req.once.Do(func() {
+		recorder := &doubles.RecorderTB{TB: owner}
+		var (
+			err      error
+			finished bool
+		)
+		internal.RecoverGoexit(func() {
+			err = req.Check(recorder)
+			finished = true
+		})
+		switch {
+		case err != nil:
+			recorder.CleanupNow()
+		case !finished || recorder.IsFailed:
+			recorder.CleanupNow()
+			err = fmt.Errorf(`precondition check failed`)
+			if msgs := recorder.Messages(); 0 < len(msgs) {
+				err = fmt.Errorf(`%w: %s`, err, strings.Join(msgs, "; "))
+			}
+		default:
+			recorder.Forward()
+		}
+		req.err = err
+		if err != nil {
+			internal.Log(tb, fmt.Sprintf(`Require %q: %s`, req.Name, err.Error()))
+		} else {
+			internal.Log(tb, fmt.Sprintf(`Require %q: OK`, req.Name))
+		}
+	})
+	return req.err
+}
+
+func getRequireMod() string {
+	switch mod := os.Getenv(EnvKeyRequire); mod {
+	case requireModFail:
+		return requireModFail
+	default:
+		return requireModSkip
+	}
+}
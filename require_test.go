@@ -0,0 +1,144 @@
+package testcase_test
+
+import (
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/doubles"
+	"github.com/adamluzsi/testcase/sandbox"
+)
+
+func ExampleSpec_Require() {
+	var t *testing.T
+	s := testcase.NewSpec(t)
+
+	s.Context(`E2E`, func(s *testcase.Spec) {
+		s.Require(`local service is reachable`, func(tb testing.TB) error {
+			conn, err := net.Dial(`tcp`, `localhost:8080`)
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		})
+
+		s.Test(``, func(t *testcase.T) {
+			// test that depends on the local service
+		})
+	})
+}
+
+func TestSpec_Require(t *testing.T) {
+	s := testcase.NewSpec(t)
+	s.HasSideEffect()
+
+	var (
+		checkErr  = testcase.Let[error](s, func(t *testcase.T) error { return nil })
+		stub      = testcase.Let(s, func(t *testcase.T) *doubles.TB { return &doubles.TB{} })
+		evaluated int32
+		ran       int32
+	)
+	act := func(t *testcase.T) {
+		atomic.StoreInt32(&evaluated, 0)
+		atomic.StoreInt32(&ran, 0)
+		s := testcase.NewSpec(stub.Get(t))
+		s.Context(`with requirement`, func(s *testcase.Spec) {
+			s.Require(`the thing`, func(tb testing.TB) error {
+				atomic.AddInt32(&evaluated, 1)
+				return checkErr.Get(t)
+			})
+			for i := 0; i < 3; i++ {
+				s.Test(``, func(t *testcase.T) { atomic.AddInt32(&ran, 1) })
+			}
+		})
+		s.Test(`without requirement`, func(t *testcase.T) {})
+		sandbox.Run(s.Finish)
+		stub.Get(t).Finish()
+	}
+
+	s.When(`the precondition is met`, func(s *testcase.Spec) {
+		s.Then(`the tests are executed`, func(t *testcase.T) {
+			act(t)
+			t.Must.Equal(int32(3), atomic.LoadInt32(&ran))
+			t.Must.False(stub.Get(t).IsFailed)
+			t.Must.False(stub.Get(t).IsSkipped)
+		})
+
+		s.Then(`the precondition is evaluated only once`, func(t *testcase.T) {
+			act(t)
+			t.Must.Equal(int32(1), atomic.LoadInt32(&evaluated))
+		})
+
+		s.Then(`the result is reported in the output`, func(t *testcase.T) {
+			act(t)
+			t.Must.Contain(stub.Get(t).Logs.String(), `Require "the thing": OK`)
+		})
+	})
+
+	s.When(`the precondition is not met`, func(s *testcase.Spec) {
+		checkErr.Let(s, func(t *testcase.T) error {
+			return errors.New(`connection refused`)
+		})
+
+		s.Then(`the tests are skipped with the reason`, func(t *testcase.T) {
+			act(t)
+			t.Must.Equal(int32(0), atomic.LoadInt32(&ran))
+			t.Must.Equal(int32(1), atomic.LoadInt32(&evaluated))
+			t.Must.True(stub.Get(t).IsSkipped)
+			t.Must.False(stub.Get(t).IsFailed)
+			t.Must.Contain(stub.Get(t).Logs.String(), `requirement "the thing" is not met: connection refused`)
+		})
+
+		s.And(`the require mod is set to fail`, func(s *testcase.Spec) {
+			s.Before(func(t *testcase.T) {
+				t.SetEnv(testcase.EnvKeyRequire, `fail`)
+			})
+
+			s.Then(`the tests fail with the reason`, func(t *testcase.T) {
+				act(t)
+				t.Must.Equal(int32(0), atomic.LoadInt32(&ran))
+				t.Must.True(stub.Get(t).IsFailed)
+				t.Must.Contain(stub.Get(t).Logs.String(), `requirement "the thing" is not met: connection refused`)
+			})
+		})
+	})
+}
+
+func TestSpec_Require_checkFailsTheTB(t *testing.T) {
+	stub := &doubles.TB{}
+	s := testcase.NewSpec(stub)
+	var ran bool
+	s.Require(`x`, func(tb testing.TB) error {
+		tb.Fatal(`boom`)
+		return nil
+	})
+	s.Test(``, func(t *testcase.T) { ran = true })
+	sandbox.Run(s.Finish)
+	stub.Finish()
+	assert.False(t, ran)
+	assert.True(t, stub.IsSkipped)
+	assert.False(t, stub.IsFailed)
+	assert.Contain(t, stub.Logs.String(), `requirement "x" is not met: precondition check failed: boom`)
+}
+
+func TestSpec_Require_cleanupAfterSubtree(t *testing.T) {
+	stub := &doubles.TB{}
+	s := testcase.NewSpec(stub)
+	var (
+		cleanedUp      bool
+		cleanedUpEarly bool
+	)
+	s.Require(`x`, func(tb testing.TB) error {
+		tb.Cleanup(func() { cleanedUp = true })
+		return nil
+	})
+	s.Test(``, func(t *testcase.T) { cleanedUpEarly = cleanedUp })
+	s.Test(``, func(t *testcase.T) { cleanedUpEarly = cleanedUpEarly || cleanedUp })
+	s.Finish()
+	assert.False(t, cleanedUpEarly)
+	stub.Finish()
+	assert.True(t, cleanedUp)
+}
@@ -0,0 +1,129 @@
+package testcase
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+const sharedStateWarningFormat = `%q Var received the same mutable %T value as %q Var in the concurrently running %q test.
+Mutations on the value will leak between the tests.
+Please make the init block return a new value for each test, or use Var.Clone to receive a copy of a shared template value.`
+
+// DetectSharedState is a SpecOption that enables, for the parallel tests of the Spec,
+// the detection of mutable Var values which are shared between concurrently running tests.
+// Let init blocks that return a package-level map or a captured pointer
+// will make tests leak their mutations to each other, and such cases are reported as test failures.
+func DetectSharedState() SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.sharedState = newSharedStateDetector()
+	})
+}
+
+func newSharedStateDetector() *sharedStateDetector {
+	return &sharedStateDetector{owners: make(map[uintptr]sharedStateOwner)}
+}
+
+// sharedStateDetector keeps track of the pointer identities of the mutable Var values,
+// which are in use by the currently running tests.
+type sharedStateDetector struct {
+	mutex  sync.Mutex
+	owners map[uintptr]sharedStateOwner
+}
+
+type sharedStateOwner struct {
+	TB      testing.TB
+	VarName string
+}
+
+func (spec *Spec) lookupSharedStateDetector() (*sharedStateDetector, bool) {
+	for _, s := range spec.specsFromCurrent() {
+		if s.sharedState != nil {
+			return s.sharedState, true
+		}
+	}
+	return nil, false
+}
+
+func (t *T) detectSharedState(varName string, value any) {
+	t.TB.Helper()
+	if !t.spec.isParallel() {
+		return
+	}
+	detector, ok := t.spec.lookupSharedStateDetector()
+	if !ok {
+		return
+	}
+	detector.Register(t, varName, value)
+}
+
+// Register registers the pointer identities of a Var value for the current test,
+// and reports if any of them is already in use by another test.
+// The registration is released when the test is finished.
+func (d *sharedStateDetector) Register(t *T, varName string, value any) {
+	t.TB.Helper()
+	ids := pointerIdentities(reflect.ValueOf(value), nil)
+	if len(ids) == 0 {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for _, id := range ids {
+		if owner, ok := d.owners[id]; ok && owner.TB != t.TB {
+			t.TB.Errorf(sharedStateWarningFormat, varName, value, owner.VarName, owner.TB.Name())
+			return
+		}
+	}
+	for _, id := range ids {
+		if _, ok := d.owners[id]; !ok {
+			d.owners[id] = sharedStateOwner{TB: t.TB, VarName: varName}
+		}
+	}
+	t.Cleanup(func() { d.release(t.TB, ids) })
+}
+
+func (d *sharedStateDetector) release(tb testing.TB, ids []uintptr) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for _, id := range ids {
+		if owner, ok := d.owners[id]; ok && owner.TB == tb {
+			delete(d.owners, id)
+		}
+	}
+}
+
+// pointerIdentities collects the addresses of the mutable references in the value.
+// Pointers are not followed, because the identity of the top level reference already tells if a value is shared.
+// Unexported struct fields are ignored, as they often point to shared immutable values, like time.Location.
+func pointerIdentities(rv reflect.Value, ids []uintptr) []uintptr {
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if !rv.IsNil() && rv.Type().Elem().Size() != 0 {
+			ids = append(ids, rv.Pointer())
+		}
+	case reflect.Map, reflect.Chan:
+		if !rv.IsNil() {
+			ids = append(ids, rv.Pointer())
+		}
+	case reflect.Slice:
+		if !rv.IsNil() && rv.Cap() != 0 && rv.Type().Elem().Size() != 0 {
+			ids = append(ids, rv.Pointer())
+		}
+	case reflect.Interface:
+		if !rv.IsNil() {
+			ids = pointerIdentities(rv.Elem(), ids)
+		}
+	case reflect.Array:
+		for i, l := 0, rv.Len(); i < l; i++ {
+			ids = pointerIdentities(rv.Index(i), ids)
+		}
+	case reflect.Struct:
+		for i, l := 0, rv.NumField(); i < l; i++ {
+			if !rv.Type().Field(i).IsExported() {
+				continue
+			}
+			ids = pointerIdentities(rv.Field(i), ids)
+		}
+	}
+	return ids
+}
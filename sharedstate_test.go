@@ -0,0 +1,169 @@
+package testcase_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal/doubles"
+)
+
+// concurrentRunner is a testcase.TBRunner that runs its sub-tests concurrently.
+type concurrentRunner struct {
+	*doubles.TB
+
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	subs []*doubles.TB
+}
+
+func (r *concurrentRunner) Run(_ string, blk func(tb testing.TB)) bool {
+	sub := &doubles.TB{}
+	r.mu.Lock()
+	r.subs = append(r.subs, sub)
+	r.mu.Unlock()
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer sub.Finish()
+		blk(sub)
+	}()
+	return true
+}
+
+func (r *concurrentRunner) Wait() []*doubles.TB {
+	r.wg.Wait()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.subs
+}
+
+func TestDetectSharedState(t *testing.T) {
+	type Entity struct{ Tags map[string]string }
+
+	runConcurrently := func(tb testing.TB, opts []testcase.SpecOption, v func(s *testcase.Spec) func(t *testcase.T)) []*doubles.TB {
+		runner := &concurrentRunner{TB: &doubles.TB{}}
+		s := testcase.NewSpec(runner, opts...)
+		s.Parallel()
+		get := v(s)
+		var barrier sync.WaitGroup
+		barrier.Add(2)
+		for i := 0; i < 2; i++ {
+			s.Test(``, func(t *testcase.T) {
+				get(t)
+				barrier.Done()
+				barrier.Wait()
+			})
+		}
+		s.Finish()
+		return runner.Wait()
+	}
+	failed := func(subs []*doubles.TB) (logs string, ok bool) {
+		for _, sub := range subs {
+			if sub.IsFailed {
+				return sub.Logs.String(), true
+			}
+		}
+		return "", false
+	}
+
+	var template = &Entity{Tags: map[string]string{"foo": "bar"}}
+
+	t.Run(`shared pointer returned from the init block is reported`, func(t *testing.T) {
+		subs := runConcurrently(t, []testcase.SpecOption{testcase.DetectSharedState()}, func(s *testcase.Spec) func(t *testcase.T) {
+			v := testcase.Let(s, func(t *testcase.T) *Entity { return template })
+			return func(t *testcase.T) { v.Get(t) }
+		})
+		logs, ok := failed(subs)
+		assert.True(t, ok)
+		assert.Contain(t, logs, `received the same mutable *testcase_test.Entity value`)
+		assert.Contain(t, logs, `concurrently running`)
+	})
+
+	t.Run(`shared map in a struct field is reported`, func(t *testing.T) {
+		subs := runConcurrently(t, []testcase.SpecOption{testcase.DetectSharedState()}, func(s *testcase.Spec) func(t *testcase.T) {
+			v := testcase.Let(s, func(t *testcase.T) Entity { return Entity{Tags: template.Tags} })
+			return func(t *testcase.T) { v.Get(t) }
+		})
+		_, ok := failed(subs)
+		assert.True(t, ok)
+	})
+
+	t.Run(`new values per test are not reported`, func(t *testing.T) {
+		subs := runConcurrently(t, []testcase.SpecOption{testcase.DetectSharedState()}, func(s *testcase.Spec) func(t *testcase.T) {
+			v := testcase.Let(s, func(t *testcase.T) *Entity { return &Entity{Tags: map[string]string{}} })
+			return func(t *testcase.T) { v.Get(t) }
+		})
+		logs, ok := failed(subs)
+		assert.False(t, ok, logs)
+	})
+
+	t.Run(`without the option, shared values are not checked`, func(t *testing.T) {
+		subs := runConcurrently(t, nil, func(s *testcase.Spec) func(t *testcase.T) {
+			v := testcase.Let(s, func(t *testcase.T) *Entity { return template })
+			return func(t *testcase.T) { v.Get(t) }
+		})
+		_, ok := failed(subs)
+		assert.False(t, ok)
+	})
+
+	t.Run(`shared template with Var.Clone is not reported`, func(t *testing.T) {
+		subs := runConcurrently(t, []testcase.SpecOption{testcase.DetectSharedState()}, func(s *testcase.Spec) func(t *testcase.T) {
+			v := testcase.Var[*Entity]{
+				ID:    `entity`,
+				Init:  func(t *testcase.T) *Entity { return template },
+				Clone: true,
+			}.Bind(s)
+			return func(t *testcase.T) { v.Get(t) }
+		})
+		logs, ok := failed(subs)
+		assert.False(t, ok, logs)
+	})
+}
+
+func TestVar_Clone(t *testing.T) {
+	s := testcase.NewSpec(t)
+
+	template := map[string][]int{"foo": {1, 2, 3}}
+
+	v := testcase.Var[map[string][]int]{
+		ID:    `template`,
+		Init:  func(t *testcase.T) map[string][]int { return template },
+		Clone: true,
+	}
+
+	s.Test(`value from Var.Init`, func(t *testcase.T) {
+		got := v.Get(t)
+		t.Must.Equal(template, got)
+		got["foo"][0] = 42
+		got["bar"] = nil
+		t.Must.Equal(map[string][]int{"foo": {1, 2, 3}}, template)
+	})
+
+	s.Context(`bound with Let`, func(s *testcase.Spec) {
+		v.Let(s, func(t *testcase.T) map[string][]int { return template })
+
+		s.Test(`value from the Let block`, func(t *testcase.T) {
+			got := v.Get(t)
+			t.Must.Equal(template, got)
+			got["foo"][0] = 42
+			t.Must.Equal(map[string][]int{"foo": {1, 2, 3}}, template)
+		})
+	})
+}
+
+func TestVar_Clone_valueWithUnexportedFields(t *testing.T) {
+	type inner struct{ a int }
+	s := testcase.NewSpec(t)
+
+	v := testcase.Var[any]{
+		ID:    `template`,
+		Init:  func(t *testcase.T) any { return inner{a: 1} },
+		Clone: true,
+	}
+
+	s.Test(``, func(t *testcase.T) {
+		t.Must.Equal(any(inner{a: 1}), v.Get(t))
+	})
+}
@@ -15,6 +15,8 @@ func newVariables() *variables {
 		onLet:  make(map[string]struct{}),
 		locks:  make(map[string]*sync.RWMutex),
 		before: make(map[string]struct{}),
+		deps:   make(map[string]map[string]struct{}),
+		reads:  make(map[string]struct{}),
 	}
 }
 
@@ -30,6 +32,11 @@ type variables struct {
 	before map[string]struct{}
 	cache  map[string]any
 	scache *variablesSuperCache
+	// deps is the dependency graph between variables,
+	// where an edge means that the init block of a variable accessed another variable.
+	deps map[string]map[string]struct{}
+	// reads holds the declared variables that were accessed by at least one test.
+	reads map[string]struct{}
 }
 
 type variablesInitBlock func(t *T) any
@@ -51,6 +58,8 @@ func (v *variables) Let(varName string, blk variablesInitBlock /* [interface{}]
 }
 
 func (v *variables) let(varName string, blk variablesInitBlock /* [interface{}] */) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
 	v.defs[varName] = blk
 }
 
@@ -64,8 +73,10 @@ func (v *variables) Get(t *T, varName string) interface{} {
 	}
 	defer v.lock(varName)()
 	if !v.cacheHas(varName) {
+		value := v.defs[varName](t.withVarInit(varName))
+		t.detectSharedState(varName, value)
 		// cacheSet(varName, ...) is protected from concurrent access by lock(varName).
-		v.cacheSet(varName, v.defs[varName](t))
+		v.cacheSet(varName, value)
 	}
 	return t.vars.cacheGet(varName)
 }
@@ -102,6 +113,7 @@ func (v *variables) reset() {
 	defer v.mutex.Unlock()
 	v.cache = make(map[string]interface{})
 	v.scache = newVariablesSuperCache()
+	v.deps = make(map[string]map[string]struct{})
 }
 
 func (v *variables) fatalMessageFor(varName string) string {
@@ -119,6 +131,8 @@ func (v *variables) fatalMessageFor(varName string) string {
 }
 
 func (v *variables) merge(oth *variables) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
 	for key, value := range oth.defs {
 		v.defs[key] = value
 	}